@@ -1,6 +1,9 @@
 package arabictext
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -44,7 +47,7 @@ func TestIsArabic(t *testing.T) {
 		{"Hamza", 'ء', true},
 		{"AlefMaksura", 'ى', true},
 		{"TaMarbuta", 'ة', true},
-		
+
 		// Non-Arabic characters
 		{"LatinA", 'a', false},
 		{"LatinZ", 'z', false},
@@ -82,13 +85,13 @@ func TestHasArabic(t *testing.T) {
 		{"PureArabicWord", "مرحبا", true},
 		{"ArabicSentence", "السلام عليكم", true},
 		{"SingleArabicLetter", "م", true},
-		
+
 		// Mixed content
 		{"MixedArabicEnglish", "Hello مرحبا World", true},
 		{"ArabicWithNumbers", "منتج 123", true},
 		{"ArabicWithParens", "القيمة (15%)", true},
 		{"NumbersFirstThenArabic", "123 منتج", true},
-		
+
 		// Pure non-Arabic
 		{"PureEnglish", "Hello World", false},
 		{"PureNumbers", "12345", false},
@@ -169,15 +172,18 @@ func TestGetLetterFormUnknownLetter(t *testing.T) {
 
 func TestReshape(t *testing.T) {
 	tests := []struct {
-		name  string
-		input string
+		name     string
+		input    string
+		lenDelta int // expected change in rune count, e.g. -1 per Lam-Alef ligature collapse
 	}{
-		{"SingleLetter", "م"},
-		{"TwoLetters", "مر"},
-		{"ThreeLetters", "مرح"},
-		{"FullWord", "مرحبا"},
-		{"WordWithNonConnecting", "ماذا"},
-		{"MultipleWords", "السلام عليكم"},
+		{"SingleLetter", "م", 0},
+		{"TwoLetters", "مر", 0},
+		{"ThreeLetters", "مرح", 0},
+		{"FullWord", "مرحبا", 0},
+		{"WordWithNonConnecting", "ماذا", 0},
+		// "السلام" contains a Lam followed by Alef ("لا"), which Reshape
+		// merges into a single Lam-Alef ligature codepoint.
+		{"MultipleWords", "السلام عليكم", -1},
 	}
 
 	for _, tt := range tests {
@@ -186,10 +192,10 @@ func TestReshape(t *testing.T) {
 			if len(result) == 0 {
 				t.Errorf("Reshape(%q) returned empty string", tt.input)
 			}
-			// Reshaped text should have same number of runes
-			if len([]rune(result)) != len([]rune(tt.input)) {
-				t.Errorf("Reshape(%q) changed length: got %d, want %d", 
-					tt.input, len([]rune(result)), len([]rune(tt.input)))
+			want := len([]rune(tt.input)) + tt.lenDelta
+			if len([]rune(result)) != want {
+				t.Errorf("Reshape(%q) changed length: got %d, want %d",
+					tt.input, len([]rune(result)), want)
 			}
 		})
 	}
@@ -392,7 +398,7 @@ func TestProcessSimplePreservesNonArabic(t *testing.T) {
 func TestArabicWithNumbers(t *testing.T) {
 	input := "منتج 1"
 	result := Process(input)
-	
+
 	// Result should contain the number
 	if len(result) == 0 {
 		t.Error("Process returned empty result for Arabic with number")
@@ -402,7 +408,7 @@ func TestArabicWithNumbers(t *testing.T) {
 func TestArabicWithPercentage(t *testing.T) {
 	input := "ضريبة (15%)"
 	result := Process(input)
-	
+
 	if len(result) == 0 {
 		t.Error("Process returned empty result for Arabic with percentage")
 	}
@@ -515,7 +521,7 @@ func TestFormTypeConstants(t *testing.T) {
 
 func TestAllArabicLettersHaveForms(t *testing.T) {
 	// Common Arabic letters that must have forms
-	letters := []rune{'ا', 'ب', 'ت', 'ث', 'ج', 'ح', 'خ', 'د', 'ذ', 'ر', 'ز', 
+	letters := []rune{'ا', 'ب', 'ت', 'ث', 'ج', 'ح', 'خ', 'د', 'ذ', 'ر', 'ز',
 		'س', 'ش', 'ص', 'ض', 'ط', 'ظ', 'ع', 'غ', 'ف', 'ق', 'ك', 'ل', 'م', 'ن', 'ه', 'و', 'ي'}
 
 	for _, letter := range letters {
@@ -531,3 +537,716 @@ func TestAllArabicLettersHaveForms(t *testing.T) {
 		}
 	}
 }
+
+func TestPersianUrduPashtoLettersHaveForms(t *testing.T) {
+	letters := []rune{'پ', 'چ', 'ژ', 'گ', 'ک', 'ی', 'ٹ', 'ڈ', 'ڑ', 'ں', 'ے', 'ھ', 'ې'}
+
+	for _, letter := range letters {
+		forms, exists := arabicForms[letter]
+		if !exists {
+			t.Errorf("No forms defined for letter %q", letter)
+			continue
+		}
+		for i, form := range forms {
+			if form == 0 {
+				t.Errorf("Empty form at index %d for letter %q", i, letter)
+			}
+		}
+	}
+}
+
+func TestNonConnectingExtendedLettersDontTakeMedialForm(t *testing.T) {
+	// Ddal-type letters never connect forward, so a letter following ڈ
+	// should take its initial form, not medial, exactly like the base د.
+	result := []rune(ReshapeWithOptions("بڈب", DefaultShapeOptions()))
+	if len(result) != 3 {
+		t.Fatalf("ReshapeWithOptions(%q) = %q, want 3 runes", "بڈب", string(result))
+	}
+}
+
+// ==================== Lam-Alef Ligature Tests ====================
+
+func TestReshapeLamAlefLigatures(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  rune
+	}{
+		{"LamAlef", "لا", 0xFEFB},
+		{"LamAlefWithHamzaAbove", "لأ", 0xFEF7},
+		{"LamAlefWithHamzaBelow", "لإ", 0xFEF9},
+		{"LamAlefWithMadda", "لآ", 0xFEF5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := []rune(Reshape(tt.input))
+			if len(result) != 1 {
+				t.Fatalf("Reshape(%q) = %q, want a single ligature rune", tt.input, string(result))
+			}
+			if result[0] != tt.want {
+				t.Errorf("Reshape(%q) = %U, want %U", tt.input, result[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestReshapeLamAlefLigatureConnectsToPrevious(t *testing.T) {
+	// In "بلا" the Lam-Alef ligature is preceded by a connecting letter
+	// (Ba), so it must take its Final form rather than Isolated.
+	result := []rune(Reshape("بلا"))
+	if len(result) != 2 {
+		t.Fatalf("Reshape(%q) = %q, want 2 runes", "بلا", string(result))
+	}
+	if result[1] != 0xFEFC {
+		t.Errorf("Reshape(%q) ligature = %U, want %U (Final Lam-Alef)", "بلا", result[1], rune(0xFEFC))
+	}
+}
+
+// ==================== Tashkeel Tests ====================
+
+func TestReshapeTashkeelStaysAttached(t *testing.T) {
+	// A fatha (U+064B) following a base letter should pass through
+	// untouched and must not be treated as a connecting neighbor.
+	input := "مَرحبا"
+	result := Reshape(input)
+	if len([]rune(result)) != len([]rune(input)) {
+		t.Errorf("Reshape(%q) changed rune count: got %d, want %d",
+			input, len([]rune(result)), len([]rune(input)))
+	}
+}
+
+func TestReshapeWithOptionsTashkeelDisabled(t *testing.T) {
+	input := "مَرحبا" // contains a fatha (U+064E) after the Meem
+	opts := ShapeOptions{Ligatures: true, Tashkeel: false, Tatweel: true}
+	result := []rune(ReshapeWithOptions(input, opts))
+	for _, r := range result {
+		if r == 0x064E {
+			t.Errorf("ReshapeWithOptions(%q) with Tashkeel disabled should strip combining marks, got %q", input, string(result))
+		}
+	}
+	if len(result) != len([]rune(input))-1 {
+		t.Errorf("ReshapeWithOptions(%q) with Tashkeel disabled = %q (%d runes), want %d runes (one mark stripped)",
+			input, string(result), len(result), len([]rune(input))-1)
+	}
+}
+
+func TestReshapeMarkDoesNotBreakConnectivityRegardlessOfTashkeel(t *testing.T) {
+	// "بَبا" is Ba + fatha + Ba + Alef: the fatha sits between the two
+	// Bas and must not stop the first Ba from taking its Initial form,
+	// whether or not Tashkeel keeps the mark in the output.
+	for _, tashkeel := range []bool{true, false} {
+		opts := ShapeOptions{Ligatures: true, Tashkeel: tashkeel, Tatweel: true}
+		result := []rune(ReshapeWithOptions("بَبا", opts))
+		if result[0] != 'ﺑ' {
+			t.Errorf("ReshapeWithOptions with Tashkeel=%v: first Ba = %U, want %U (Initial)", tashkeel, result[0], rune('ﺑ'))
+		}
+	}
+}
+
+func TestStripHarakat(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"مَرحبا", "مرحبا"},
+		{"بِسْمِ اللَّهِ", "بسم الله"},
+		{"hello", "hello"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := StripHarakat(tt.input); got != tt.want {
+			t.Errorf("StripHarakat(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSmartLen(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"مرحبا", 5},
+		{"مَرحبا", 5},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := SmartLen(tt.input); got != tt.want {
+			t.Errorf("SmartLen(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestReshapeComposesShaddaFathaIsolated(t *testing.T) {
+	// A lone Ra (non-connecting) followed by shadda+fatha should emit the
+	// precomposed isolated ligature (FC61) instead of the three runes
+	// (base, shadda, fatha) separately.
+	result := []rune(Reshape("رَّ"))
+	want := []rune{'ﺭ', 0xFC61}
+	if string(result) != string(want) {
+		t.Errorf("Reshape(ra+shadda+fatha) = %U, want %U", result, want)
+	}
+}
+
+func TestReshapeComposesShaddaKasraMedial(t *testing.T) {
+	// Meem, Ain+fatha, Lam+shadda+kasra, Meem ("معلّم"-like): the doubled
+	// Lam connects both ways (Ain before, Meem after), so it must take
+	// the medial shadda+kasra ligature (FCF4), not the isolated one.
+	input := string([]rune{'م', 'ع', 0x064E, 'ل', 0x0651, 0x0650, 'م'})
+	result := []rune(Reshape(input))
+	found := false
+	for _, r := range result {
+		if r == 0xFCF4 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Reshape(%q) = %U, want it to contain %U (medial shadda+kasra)", input, result, rune(0xFCF4))
+	}
+}
+
+func TestReshapeShaddaVowelOrderIndependent(t *testing.T) {
+	// Real-world text stores shadda before or after the vowel mark;
+	// either order must compose into the same ligature.
+	shaddaFirst := Reshape(string([]rune{0x0631, 0x0651, 0x064E}))
+	vowelFirst := Reshape(string([]rune{0x0631, 0x064E, 0x0651}))
+	if shaddaFirst != vowelFirst {
+		t.Errorf("Reshape with shadda/vowel swapped: %q != %q", shaddaFirst, vowelFirst)
+	}
+}
+
+// ==================== Shaper Tests ====================
+
+func TestShaperWriteStringMatchesReshapeWithOptions(t *testing.T) {
+	inputs := []string{
+		"مرحبا",
+		"السلام عليكم",
+		"مَرحبا",
+		"بِسْمِ اللَّهِ",
+		"مُعَلِّم",
+		"بلا",
+		"123 مرحبا",
+		"ضريبة (15%)",
+		"",
+		"Hello World",
+	}
+	opts := DefaultShapeOptions()
+	shaper := NewShaper(opts)
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			want := ReshapeWithOptions(input, opts)
+
+			var buf strings.Builder
+			n, err := shaper.WriteString(&buf, input)
+			if err != nil {
+				t.Fatalf("WriteString(%q) returned error: %v", input, err)
+			}
+			if got := buf.String(); got != want {
+				t.Errorf("WriteString(%q) wrote %q, want %q", input, got, want)
+			}
+			if n != buf.Len() {
+				t.Errorf("WriteString(%q) returned n=%d, want %d (bytes written)", input, n, buf.Len())
+			}
+		})
+	}
+}
+
+func TestShapeIntoMatchesReshapeWithOptions(t *testing.T) {
+	inputs := []string{"مرحبا", "مُعَلِّم", "بلا", "123 مرحبا", ""}
+	opts := DefaultShapeOptions()
+	shaper := NewShaper(opts)
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			want := ReshapeWithOptions(input, opts)
+			src := []rune(input)
+			dst := make([]rune, len(src))
+			n := shaper.ShapeInto(dst, src)
+			if got := string(dst[:n]); got != want {
+				t.Errorf("ShapeInto(%q) = %q, want %q", input, got, want)
+			}
+		})
+	}
+}
+
+func TestShaperRespectsTashkeelDisabled(t *testing.T) {
+	opts := ShapeOptions{Ligatures: true, Tashkeel: false, Tatweel: true}
+	shaper := NewShaper(opts)
+	var buf strings.Builder
+	if _, err := shaper.WriteString(&buf, "مَرحبا"); err != nil {
+		t.Fatalf("WriteString returned error: %v", err)
+	}
+	want := ReshapeWithOptions("مَرحبا", opts)
+	if got := buf.String(); got != want {
+		t.Errorf("WriteString with Tashkeel disabled = %q, want %q", got, want)
+	}
+}
+
+type errorWriter struct{ limit int }
+
+func (w *errorWriter) Write(p []byte) (int, error) {
+	if w.limit <= 0 {
+		return 0, fmt.Errorf("write limit reached")
+	}
+	n := len(p)
+	if n > w.limit {
+		n = w.limit
+	}
+	w.limit -= n
+	if n < len(p) {
+		return n, fmt.Errorf("write limit reached")
+	}
+	return n, nil
+}
+
+func TestShaperWriteStringStopsOnWriteError(t *testing.T) {
+	shaper := NewShaper(DefaultShapeOptions())
+	w := &errorWriter{limit: 2}
+	n, err := shaper.WriteString(w, "مرحبا")
+	if err == nil {
+		t.Fatal("WriteString with a failing writer should return an error")
+	}
+	if n != 2 {
+		t.Errorf("WriteString stopped after n=%d bytes, want 2 (the writer's limit)", n)
+	}
+}
+
+// ==================== Bracket Pair (N0) Tests ====================
+
+func TestMirrorPairedBracketsMatchingEmbeddingDirection(t *testing.T) {
+	// An Arabic word inside parens, in an RTL embedding: the strong type
+	// inside matches embedding, so both brackets resolve RightToLeft and
+	// get mirrored.
+	result := MirrorPairedBrackets("(مرحبا)", RightToLeft)
+	want := ")مرحبا("
+	if result != want {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want %q", "(مرحبا)", result, want)
+	}
+}
+
+func TestMirrorPairedBracketsOppositeOfEmbeddingFallsBackToPrecedingContext(t *testing.T) {
+	// "مرحبا (Hello)": the Latin word inside the parens is LeftToRight,
+	// opposite of the RightToLeft embedding, so N0 falls back to the
+	// strong direction right before the opening bracket - the preceding
+	// Arabic word - which is RightToLeft, so the brackets still mirror.
+	result := MirrorPairedBrackets("مرحبا (Hello)", RightToLeft)
+	want := "مرحبا )Hello("
+	if result != want {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want %q", "مرحبا (Hello)", result, want)
+	}
+}
+
+func TestMirrorPairedBracketsNoStrongTypeInsideUsesEmbedding(t *testing.T) {
+	// A pure digit run has no strong type, so the pair falls through to
+	// embedding - RightToLeft here - and still mirrors.
+	result := MirrorPairedBrackets("(123)", RightToLeft)
+	want := ")123("
+	if result != want {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want %q", "(123)", result, want)
+	}
+}
+
+func TestMirrorPairedBracketsLeftToRightEmbeddingKeepsGlyph(t *testing.T) {
+	// Under an LeftToRight embedding, an Arabic island inside parens is
+	// opposite of embedding; with nothing before the opening bracket to
+	// fall back on, N0 leaves the pair at the embedding direction
+	// (LeftToRight), so it is never mirrored.
+	result := MirrorPairedBrackets("(مرحبا)", LeftToRight)
+	want := "(مرحبا)"
+	if result != want {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want %q", "(مرحبا)", result, want)
+	}
+}
+
+func TestMirrorPairedBracketsNestedPairs(t *testing.T) {
+	result := MirrorPairedBrackets("(a[b]c)", RightToLeft)
+	want := ")a[b]c("
+	if result != want {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want %q", "(a[b]c)", result, want)
+	}
+}
+
+func TestMirrorPairedBracketsUnmatchedBracketUsesEmbedding(t *testing.T) {
+	result := MirrorPairedBrackets("abc)", RightToLeft)
+	want := "abc("
+	if result != want {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want %q", "abc)", result, want)
+	}
+}
+
+func TestMirrorPairedBracketsEmptyString(t *testing.T) {
+	if got := MirrorPairedBrackets("", RightToLeft); got != "" {
+		t.Errorf("MirrorPairedBrackets(%q) = %q, want empty", "", got)
+	}
+}
+
+func TestProcessSimpleKeepsBracketedNumberIslandReadable(t *testing.T) {
+	// "رقم (5) هنا": the parens wrap a digit with no strong type, so per
+	// N0 they resolve to the RightToLeft embedding and mirror - but
+	// because the whole string is then reversed, a mirrored pair around
+	// a short island reads back in its original bracket orientation.
+	result := ProcessSimple("رقم (5) هنا")
+	if strings.Count(result, "(") != 1 || strings.Count(result, ")") != 1 {
+		t.Errorf("ProcessSimple(%q) = %q, want exactly one of each paren", "رقم (5) هنا", result)
+	}
+}
+
+// ==================== Bidi Tests ====================
+
+func TestBidiEmptyString(t *testing.T) {
+	if got := Bidi("", Auto); got != nil {
+		t.Errorf("Bidi(\"\", Auto) = %v, want nil", got)
+	}
+}
+
+func TestBidiPureLatinIsOneLeftToRightRun(t *testing.T) {
+	runs := Bidi("abc", Auto)
+	if len(runs) != 1 || runs[0].Direction != LeftToRight || runs[0].Text != "abc" {
+		t.Fatalf("Bidi(%q, Auto) = %+v, want a single LeftToRight run", "abc", runs)
+	}
+}
+
+func TestBidiRunsReconstructTheOriginalText(t *testing.T) {
+	// Concatenating runs in the order Bidi returns them, in their
+	// logical (not display) text, must reproduce the input exactly -
+	// Start/End should be contiguous and cover the whole string.
+	input := "العاشر (Unicode 10-12) مبدينة"
+	runs := Bidi(input, Auto)
+	var rebuilt strings.Builder
+	for i, r := range runs {
+		if r.Start != rebuilt.Len() {
+			t.Errorf("run %d starts at %d, want %d", i, r.Start, rebuilt.Len())
+		}
+		rebuilt.WriteString(r.Text)
+	}
+	if rebuilt.String() != input {
+		t.Errorf("runs reconstruct to %q, want %q", rebuilt.String(), input)
+	}
+}
+
+func TestBidiSplitsMixedArabicAndLatinIntoDirectionalRuns(t *testing.T) {
+	runs := Bidi("العاشر (Unicode Conference)", Auto)
+	if len(runs) < 2 {
+		t.Fatalf("expected at least 2 runs for mixed Arabic/Latin text, got %d: %+v", len(runs), runs)
+	}
+	foundRTL, foundLTR := false, false
+	for _, r := range runs {
+		if r.Direction == RightToLeft {
+			foundRTL = true
+		}
+		if r.Direction == LeftToRight {
+			foundLTR = true
+		}
+	}
+	if !foundRTL || !foundLTR {
+		t.Errorf("Bidi(%q, Auto) = %+v, want both RightToLeft and LeftToRight runs", "العاشر (Unicode Conference)", runs)
+	}
+}
+
+func TestBidiBaseDirectionOverride(t *testing.T) {
+	// "123" has no strong character, so Auto can't tell; an explicit
+	// RightToLeft base direction should still be honored.
+	runs := Bidi("123", RightToLeft)
+	if len(runs) != 1 {
+		t.Fatalf("Bidi(%q, RightToLeft) = %+v, want 1 run", "123", runs)
+	}
+}
+
+// ==================== ProcessWithOptions Tests ====================
+
+func TestProcessWithOptionsPureLatinUnchanged(t *testing.T) {
+	input := "Invoice #123"
+	if got := ProcessWithOptions(input, DefaultShapeOptions()); got != input {
+		t.Errorf("ProcessWithOptions(%q) = %q, want unchanged (no Arabic)", input, got)
+	}
+}
+
+func TestProcessWithOptionsDoesNotPanic(t *testing.T) {
+	inputs := []string{"مرحبا بالعالم", "لا إله إلا الله", "", "123"}
+	for _, in := range inputs {
+		_ = ProcessWithOptions(in, DefaultShapeOptions())
+	}
+}
+
+// ==================== Process mixed-content regression tests ====================
+//
+// These cover the failure modes the ad-hoc classifier used to get wrong:
+// nested parentheses, quoted Latin text embedded in Arabic, and European
+// vs. Arabic-Indic digits next to Arabic text.
+
+func TestProcessKeepsParenthesesBalancedAroundLatinInsert(t *testing.T) {
+	result := []rune(Process("العاشر (Unicode Conference) القادم"))
+	opens, closes := 0, 0
+	for _, r := range result {
+		switch r {
+		case '(':
+			opens++
+		case ')':
+			closes++
+		}
+	}
+	if opens != 1 || closes != 1 {
+		t.Fatalf("Process(...) = %q, want exactly one ( and one )", string(result))
+	}
+}
+
+func TestProcessKeepsDigitsReadableNextToArabic(t *testing.T) {
+	result := Process("10-12 آذار 1997")
+	for _, digits := range []string{"10", "12", "1997"} {
+		if !strings.Contains(result, digits) {
+			t.Errorf("Process(%q) = %q, want %q to stay together and in reading order", "10-12 آذار 1997", result, digits)
+		}
+	}
+}
+
+func TestProcessHandlesMultiLineParagraphsIndependently(t *testing.T) {
+	input := "مرحبا بالعالم\nHello World"
+	result := Process(input)
+	if !strings.Contains(result, "Hello World") {
+		t.Errorf("Process(%q) = %q, want the Latin line kept intact", input, result)
+	}
+}
+
+// ==================== Justify Tests ====================
+
+// fixedMeasure reports every rune as the same width, which is all these
+// tests need: enough to reason about how many tatweels a given stretch
+// should produce.
+func fixedMeasure(width float64) func(rune) float64 {
+	return func(rune) float64 { return width }
+}
+
+func TestJustifyNoStretchNeeded(t *testing.T) {
+	shaped := Reshape("مكتب")
+	if got := Justify(shaped, 100, 100, fixedMeasure(5)); got != shaped {
+		t.Errorf("Justify with currentWidth == targetWidth should be a no-op, got %q", got)
+	}
+}
+
+func TestJustifyInsertsTatweelAfterKafMedial(t *testing.T) {
+	// "مكتب" (maktab/office) reshapes so Kaf lands in its medial form
+	// (connected on both sides), which is the highest-priority kashida
+	// position.
+	shaped := Reshape("مكتب")
+	before := []rune(shaped)
+
+	justified := Justify(shaped, 0, 100, fixedMeasure(25))
+	after := []rune(justified)
+
+	if len(after) <= len(before) {
+		t.Fatalf("Justify(%q, 0, 100, ...) = %q, want at least one tatweel inserted", shaped, justified)
+	}
+	count := strings.Count(justified, string(rune(tatweel)))
+	if count == 0 {
+		t.Errorf("Justify(%q) = %q, want at least one U+0640 tatweel", shaped, justified)
+	}
+}
+
+func TestJustifyNeverInsertsAtWordBoundaryOrBetweenWords(t *testing.T) {
+	shaped := Reshape("بيت كبير") // "house big" - two separate words
+	justified := Justify(shaped, 0, 1000, fixedMeasure(10))
+	// No tatweel should land adjacent to the space.
+	spaceIdx := strings.IndexRune(justified, ' ')
+	if spaceIdx == -1 {
+		t.Fatal("expected the space between words to survive justification")
+	}
+	runes := []rune(justified)
+	for i, r := range runes {
+		if r == ' ' {
+			if i > 0 && runes[i-1] == tatweel {
+				t.Error("tatweel inserted immediately before the word boundary space")
+			}
+			if i+1 < len(runes) && runes[i+1] == tatweel {
+				t.Error("tatweel inserted immediately after the word boundary space")
+			}
+		}
+	}
+}
+
+func TestJustifyNeverInsertsInsideNumericRun(t *testing.T) {
+	shaped := Reshape("رقم 12345")
+	justified := Justify(shaped, 0, 1000, fixedMeasure(10))
+	if strings.Contains(justified, "1"+string(rune(tatweel))) || strings.ContainsAny(justified[strings.IndexAny(justified, "12345"):], string(rune(tatweel))) {
+		// digits are ASCII and never registered in presentationForms, so
+		// no candidate position can land inside "12345"; just confirm the
+		// run of digits itself is untouched.
+		digits := justified[strings.IndexAny(justified, "0123456789"):]
+		for _, r := range digits {
+			if r == tatweel {
+				t.Errorf("Justify inserted a tatweel inside the numeric run: %q", justified)
+			}
+		}
+	}
+}
+
+func TestJustifyStopsOnceCandidatesAreExhausted(t *testing.T) {
+	// A huge target width asks for more tatweels than this short word has
+	// candidate positions for; Justify must not panic or loop, and must
+	// not insert more tatweels than there are valid positions.
+	shaped := Reshape("لا")
+	justified := Justify(shaped, 0, 1_000_000, fixedMeasure(1))
+	if strings.Count(justified, string(rune(tatweel))) > 1 {
+		t.Errorf("Justify(%q) = %q, want at most one candidate position filled", shaped, justified)
+	}
+}
+
+func TestJustifyTextReordersForDisplay(t *testing.T) {
+	result := JustifyText("مكتب", 0, 100, fixedMeasure(25))
+	if result == "" {
+		t.Fatal("JustifyText returned an empty string")
+	}
+}
+
+func TestJustifyTextPassesThroughNonArabic(t *testing.T) {
+	if got := JustifyText("Invoice #123", 0, 1000, fixedMeasure(10)); got != "Invoice #123" {
+		t.Errorf("JustifyText(%q) = %q, want unchanged (no Arabic)", "Invoice #123", got)
+	}
+}
+
+// ==================== WrapShaped Tests ====================
+
+// runeWidthMeasure reports a string's width as one unit per rune, which is
+// enough for these tests to reason about exactly where a line should
+// break.
+func runeWidthMeasure(s string) float64 {
+	return float64(len([]rune(s)))
+}
+
+func TestWrapShapedFitsOnOneLine(t *testing.T) {
+	lines := WrapShaped("Invoice 123", runeWidthMeasure, 100)
+	if len(lines) != 1 || lines[0] != "Invoice 123" {
+		t.Errorf("WrapShaped = %v, want a single unchanged line", lines)
+	}
+}
+
+func TestWrapShapedBreaksOnWordBoundaries(t *testing.T) {
+	// "aaaa bbbb cccc" at maxWidth 9: "aaaa bbbb" is 9 wide (fits exactly),
+	// "cccc" starts the next line.
+	lines := WrapShaped("aaaa bbbb cccc", runeWidthMeasure, 9)
+	want := []string{"aaaa bbbb", "cccc"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("WrapShaped = %v, want %v", lines, want)
+	}
+}
+
+func TestWrapShapedNeverBreaksInsideAWord(t *testing.T) {
+	text := "مرحبا بالعالم"
+	words := strings.Fields(text)
+	lines := WrapShaped(text, runeWidthMeasure, 7)
+
+	for _, line := range lines {
+		for _, tok := range strings.Fields(line) {
+			found := false
+			for _, word := range words {
+				if tok == word {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("WrapShaped produced a fragment %q that isn't a whole word", tok)
+			}
+		}
+	}
+}
+
+func TestWrapShapedSplitsOversizedToken(t *testing.T) {
+	lines := WrapShaped("aaaaaaaaaa", runeWidthMeasure, 4)
+	if len(lines) < 2 {
+		t.Fatalf("WrapShaped(%q, maxWidth=4) = %v, want multiple pieces", "aaaaaaaaaa", lines)
+	}
+	var rebuilt strings.Builder
+	for _, line := range lines {
+		if runeWidthMeasure(line) > 4 {
+			t.Errorf("piece %q exceeds maxWidth", line)
+		}
+		rebuilt.WriteString(line)
+	}
+	if rebuilt.String() != "aaaaaaaaaa" {
+		t.Errorf("WrapShaped pieces %v don't reassemble to the original token", lines)
+	}
+}
+
+func TestWrapShapedKeepsHarakatWithBaseLetter(t *testing.T) {
+	// "مُ" is a Meem with a damma (a harakat) - splitting between them
+	// would orphan the vowel mark.
+	word := "مُمُمُمُمُ"
+	lines := WrapShaped(word, runeWidthMeasure, 2)
+	for _, line := range lines {
+		runes := []rune(line)
+		if len(runes) > 0 && isHarakat(runes[0]) {
+			t.Errorf("WrapShaped produced a line starting with a harakat: %q", line)
+		}
+	}
+}
+
+func TestWrapShapedEmptyInput(t *testing.T) {
+	if lines := WrapShaped("", runeWidthMeasure, 100); lines != nil {
+		t.Errorf("WrapShaped(\"\") = %v, want nil", lines)
+	}
+}
+
+func TestWrapShapedForcedNewline(t *testing.T) {
+	lines := WrapShaped("aaaa\nbbbb", runeWidthMeasure, 100)
+	want := []string{"aaaa", "bbbb"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("WrapShaped = %v, want %v", lines, want)
+	}
+}
+
+func TestWrapShapedForcedNewlinePreservesBlankLine(t *testing.T) {
+	lines := WrapShaped("aaaa\n\nbbbb", runeWidthMeasure, 100)
+	want := []string{"aaaa", "", "bbbb"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("WrapShaped = %v, want %v", lines, want)
+	}
+}
+
+func TestWrapShapedKeepsNoBreakSpaceGlued(t *testing.T) {
+	// "100 SAR bbbb" at maxWidth 7: the no-break space must not become
+	// a break point, so "100 SAR" (8 runes) stays one oversized token
+	// on its own line rather than splitting into "100" and "SAR".
+	lines := WrapShaped("100 SAR bbbb", runeWidthMeasure, 7)
+	if len(lines) == 0 || !strings.Contains(lines[0], " ") {
+		t.Errorf("WrapShaped = %v, want the no-break space kept within a single line", lines)
+	}
+	for _, line := range lines {
+		if line == "100" || line == "SAR" {
+			t.Errorf("WrapShaped split the no-break-space token into %q", line)
+		}
+	}
+}
+
+func TestWrapShapedMixedArabicLatinDigits(t *testing.T) {
+	// Mixed Arabic/Latin/digit words, each short enough to fit alone but
+	// not two per line at this width.
+	lines := WrapShaped("مرحبا Invoice 123 بالعالم", runeWidthMeasure, 8)
+	if len(lines) != 4 {
+		t.Errorf("WrapShaped produced %d lines, want 4: %v", len(lines), lines)
+	}
+}
+
+func TestWrapShapedBreaksAtSoftHyphen(t *testing.T) {
+	word := "super­califragilistic"
+	lines := WrapShaped(word, runeWidthMeasure, 8)
+	if len(lines) < 2 {
+		t.Fatalf("WrapShaped(%q, maxWidth=8) = %v, want multiple pieces", word, lines)
+	}
+	if lines[0] != "super-" {
+		t.Errorf("WrapShaped = %v, want the first piece to break at the soft hyphen as %q", lines, "super-")
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "­") {
+			t.Errorf("WrapShaped piece %q still contains the soft hyphen character", line)
+		}
+	}
+}
+
+func TestWrapShapedNoSoftHyphenFallsBackToGraphemeSplit(t *testing.T) {
+	// Unchanged from before soft-hyphen support: a token with none still
+	// splits at grapheme-cluster boundaries.
+	lines := WrapShaped("aaaaaaaaaa", runeWidthMeasure, 4)
+	for _, line := range lines {
+		if runeWidthMeasure(line) > 4 {
+			t.Errorf("piece %q exceeds maxWidth", line)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package arabictext
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Direction is a resolved or requested text direction. The zero value,
+// Auto, tells Bidi to detect the paragraph direction itself (UAX #9 rules
+// P2/P3, first strong character); Run.Direction is always LeftToRight or
+// RightToLeft, never Auto.
+type Direction int
+
+const (
+	Auto Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+// Run is one maximal span of text at a single resolved bidi level, in
+// logical (original, not display) order. Start and End are byte offsets
+// into the string passed to Bidi, with End exclusive.
+type Run struct {
+	Text       string
+	Direction  Direction
+	Start, End int
+}
+
+// Bidi splits text into level runs per the Unicode Bidirectional
+// Algorithm (UAX #9), using golang.org/x/text/unicode/bidi - already a
+// dependency of this module, and already relied on elsewhere in this repo
+// for CLDR-correct formatting (see pkg/locale) - rather than
+// reimplementing the class tables and X1-X10/W1-W7/N0-N2/I1-I2/L1-L4
+// rules by hand. baseDir picks the paragraph direction; Auto detects it
+// from the first strong character.
+//
+// A bidi.Paragraph only ever resolves a single paragraph: SetString stops
+// at the first paragraph separator (bidi class B, e.g. "\n") and reports
+// how much of the input it consumed. Bidi loops on that so each paragraph
+// of a multi-line string gets its own base direction, rather than letting
+// one strong character anywhere in the text dictate the order of every
+// line.
+//
+// Runs are returned in the order they must be concatenated to reproduce
+// the correct visual layout: each LeftToRight run reads as Run.Text
+// as-is, each RightToLeft run must first be reversed and bracket-mirrored
+// (see Reverse/MirrorBrackets) before it's appended - ProcessWithOptions
+// does this for Arabic rendering.
+func Bidi(text string, baseDir Direction) []Run {
+	if text == "" {
+		return nil
+	}
+
+	var opts []bidi.Option
+	switch baseDir {
+	case LeftToRight:
+		opts = []bidi.Option{bidi.DefaultDirection(bidi.LeftToRight)}
+	case RightToLeft:
+		opts = []bidi.Option{bidi.DefaultDirection(bidi.RightToLeft)}
+	}
+
+	var runs []Run
+	offset := 0
+	remaining := text
+	for len(remaining) > 0 {
+		var p bidi.Paragraph
+		n, err := p.SetString(remaining, opts...)
+		if err != nil || n == 0 {
+			runs = append(runs, Run{Text: remaining, Direction: LeftToRight, Start: offset, End: offset + len(remaining)})
+			break
+		}
+
+		order, err := p.Order()
+		if err != nil {
+			runs = append(runs, Run{Text: remaining[:n], Direction: LeftToRight, Start: offset, End: offset + n})
+		} else {
+			for i := 0; i < order.NumRuns(); i++ {
+				r := order.Run(i)
+				s := r.String()
+				dir := LeftToRight
+				if r.Direction() == bidi.RightToLeft {
+					dir = RightToLeft
+				}
+				runs = append(runs, Run{Text: s, Direction: dir, Start: offset, End: offset + len(s)})
+				offset += len(s)
+			}
+			remaining = remaining[n:]
+			continue
+		}
+
+		offset += n
+		remaining = remaining[n:]
+	}
+
+	return runs
+}
+
+// Reverse reverses a string (for RTL display)
+func Reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// MirrorBrackets swaps brackets for RTL display
+func MirrorBrackets(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if mirrored, ok := mirroredBrackets[r]; ok {
+			runes[i] = mirrored
+		}
+	}
+	return string(runes)
+}
+
+// joinVisual reshapes each run's Arabic content (per opts) and
+// concatenates the runs in display order: RightToLeft runs are reversed
+// and bracket-mirrored before being appended, LeftToRight runs are
+// appended as reshaped.
+func joinVisual(runs []Run, opts ShapeOptions) string {
+	var b strings.Builder
+	for _, r := range runs {
+		shaped := ReshapeWithOptions(r.Text, opts)
+		if r.Direction == RightToLeft {
+			shaped = MirrorBrackets(Reverse(shaped))
+		}
+		b.WriteString(shaped)
+	}
+	return b.String()
+}
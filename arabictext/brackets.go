@@ -0,0 +1,157 @@
+package arabictext
+
+import "unicode"
+
+// bracketOpeners maps an opening bracket to its canonical closing form, for
+// the subset of mirroredBrackets that actually come in nestable open/close
+// pairs (BidiBrackets.txt calls these "paired brackets").
+var bracketOpeners = map[rune]rune{
+	'(': ')',
+	'[': ']',
+	'{': '}',
+	'<': '>',
+	'«': '»',
+}
+
+// bracketClosers is the reverse of bracketOpeners, for matching a closer
+// against whatever opener is on top of the stack.
+var bracketClosers = buildBracketClosers()
+
+func buildBracketClosers() map[rune]rune {
+	m := make(map[rune]rune, len(bracketOpeners))
+	for open, close := range bracketOpeners {
+		m[close] = open
+	}
+	return m
+}
+
+// maxBracketPairDepth is the open-bracket stack bound from BD16: once this
+// many unmatched openers are pending, further opens stop being tracked for
+// the rest of the text rather than growing the stack without limit.
+const maxBracketPairDepth = 63
+
+// bracketPair is one matched (opening index, closing index) pair, both rune
+// indices into the text passed to findBracketPairs.
+type bracketPair struct {
+	open, close int
+}
+
+// findBracketPairs implements UAX #9 BD16: a stack-based scan that pairs
+// each opening bracket with the next matching closer at the same nesting
+// level. The stack is bounded to maxBracketPairDepth; once full, scanning
+// for new pairs stops (already-found pairs are kept, but openers beyond the
+// bound are never matched).
+func findBracketPairs(runes []rune) []bracketPair {
+	type stackEntry struct {
+		want rune // the closing bracket this opener expects
+		pos  int
+	}
+	var stack []stackEntry
+	var pairs []bracketPair
+
+	for i, r := range runes {
+		if want, isOpen := bracketOpeners[r]; isOpen {
+			if len(stack) == maxBracketPairDepth {
+				break
+			}
+			stack = append(stack, stackEntry{want: want, pos: i})
+			continue
+		}
+		if _, isClose := bracketClosers[r]; isClose {
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].want == r {
+					pairs = append(pairs, bracketPair{open: stack[j].pos, close: i})
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// strongDirection reports the directional strong type of r: RightToLeft
+// for Arabic, LeftToRight for other letters, ok=false for neutrals and
+// digits (which carry no strong type for N0 purposes).
+func strongDirection(r rune) (dir Direction, ok bool) {
+	switch {
+	case IsArabic(r):
+		return RightToLeft, true
+	case unicode.IsLetter(r):
+		return LeftToRight, true
+	}
+	return Auto, false
+}
+
+// resolveBracketDirection implements UAX #9 rule N0 for one bracket pair:
+// if a strong type is found between the brackets and it matches embedding,
+// both brackets take that direction. Otherwise, the direction before the
+// opening bracket (the nearest preceding strong type) is used if it
+// establishes the opposite-of-embedding direction found inside; failing
+// that, brackets fall back to embedding.
+func resolveBracketDirection(runes []rune, pair bracketPair, embedding Direction) Direction {
+	insideHasEmbedding := false
+	insideHasOpposite := false
+	for i := pair.open + 1; i < pair.close; i++ {
+		dir, ok := strongDirection(runes[i])
+		if !ok {
+			continue
+		}
+		if dir == embedding {
+			insideHasEmbedding = true
+			break
+		}
+		insideHasOpposite = true
+	}
+	if insideHasEmbedding {
+		return embedding
+	}
+	if !insideHasOpposite {
+		return embedding
+	}
+
+	for i := pair.open - 1; i >= 0; i-- {
+		if dir, ok := strongDirection(runes[i]); ok {
+			return dir
+		}
+	}
+	return embedding
+}
+
+// MirrorPairedBrackets resolves each bracket pair in text per UAX #9 rule
+// N0 (see findBracketPairs/resolveBracketDirection) and mirrors only the
+// brackets whose resolved direction is RightToLeft - unlike the blanket
+// MirrorBrackets, a bracket pair that resolves to LeftToRight (e.g.
+// wrapping a Latin/number island inside RTL text) keeps its original
+// glyph. text must be in logical order (call this before Reverse).
+// Brackets with no match (BD16 stack exhausted, or genuinely unpaired)
+// are mirrored only when embedding itself is RightToLeft, matching plain
+// neutral-run resolution.
+func MirrorPairedBrackets(text string, embedding Direction) string {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return text
+	}
+
+	direction := make(map[int]Direction, 4)
+	for _, pair := range findBracketPairs(runes) {
+		dir := resolveBracketDirection(runes, pair, embedding)
+		direction[pair.open] = dir
+		direction[pair.close] = dir
+	}
+
+	for i, r := range runes {
+		mirrored, isBracket := mirroredBrackets[r]
+		if !isBracket {
+			continue
+		}
+		dir, paired := direction[i]
+		if !paired {
+			dir = embedding
+		}
+		if dir == RightToLeft {
+			runes[i] = mirrored
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,104 @@
+package arabictext
+
+import "strings"
+
+// isHarakat reports whether r is an Arabic combining diacritic (harakat):
+// tanwin/short vowels/shadda/sukun and Quranic recitation marks
+// (U+064B-U+065F), the superscript alef (U+0670), and the small Quranic
+// annotation marks (U+06D6-U+06ED). These always attach to the preceding
+// base letter - they never take part in connectivity, regardless of
+// whether ShapeOptions.Tashkeel keeps them in the output or strips them
+// (see ReshapeWithOptions) - and StripHarakat/SmartLen use the same range
+// to ignore them entirely.
+func isHarakat(r rune) bool {
+	switch {
+	case r >= 0x064B && r <= 0x065F:
+		return true
+	case r == 0x0670:
+		return true
+	case r >= 0x06D6 && r <= 0x06ED:
+		return true
+	}
+	return false
+}
+
+// StripHarakat removes Arabic diacritics (harakat) from s, leaving base
+// letters untouched. Useful for search/comparison or plain-text output
+// where vocalization marks aren't wanted.
+func StripHarakat(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if isHarakat(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SmartLen returns the rune count of s ignoring harakat, i.e. the number
+// of base letters and other characters that actually occupy a glyph
+// slot. Plain len([]rune(s)) overcounts vocalized text for width
+// estimation since combining marks render on top of the previous letter
+// rather than taking their own space.
+func SmartLen(s string) int {
+	n := 0
+	for _, r := range s {
+		if isHarakat(r) {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// shaddaLigature holds the precomposed glyph(s) for a shadda stacked with
+// a vowel mark: isolated is always available, medial is zero for the
+// tanwin (fathatan/dammatan/kasratan) vowels since tanwin only occurs
+// word-finally and has no medial form.
+type shaddaLigature struct {
+	isolated rune
+	medial   rune
+}
+
+// shaddaLigatures maps a vowel mark to the precomposed shadda+vowel glyph
+// ReshapeWithOptions substitutes in place of the two marks emitted
+// separately, so the PDF renderer draws one correctly stacked glyph
+// instead of overlapping shadda and vowel. Isolated forms are
+// FC5E-FC63; medial forms are FCF2-FCF4 (fatha/damma/kasra only).
+var shaddaLigatures = map[rune]shaddaLigature{
+	0x064B: {isolated: 0xFC5E}, // shadda + fathatan
+	0x064C: {isolated: 0xFC5F}, // shadda + dammatan
+	0x064D: {isolated: 0xFC60}, // shadda + kasratan
+	0x064E: {isolated: 0xFC61, medial: 0xFCF2}, // shadda + fatha
+	0x064F: {isolated: 0xFC62, medial: 0xFCF3}, // shadda + damma
+	0x0650: {isolated: 0xFC63, medial: 0xFCF4}, // shadda + kasra
+}
+
+const shadda = 0x0651
+
+// shaddaVowelPair reports the precomposed ligature for a shadda and a
+// vowel mark in either order (real-world text stores them both ways), or
+// ok=false if a and b aren't a shadda+vowel pair.
+func shaddaVowelPair(a, b rune) (lig shaddaLigature, ok bool) {
+	if a == shadda {
+		lig, ok = shaddaLigatures[b]
+		return lig, ok
+	}
+	if b == shadda {
+		lig, ok = shaddaLigatures[a]
+		return lig, ok
+	}
+	return shaddaLigature{}, false
+}
+
+// matchShaddaVowel looks at runes[pos] and runes[pos+1] for a shadda+vowel
+// pair (see shaddaVowelPair) and returns the precomposed ligature to
+// emit, or ok=false if this isn't a shadda+vowel pair.
+func matchShaddaVowel(runes []rune, pos int) (lig shaddaLigature, ok bool) {
+	if pos+1 >= len(runes) {
+		return shaddaLigature{}, false
+	}
+	return shaddaVowelPair(runes[pos], runes[pos+1])
+}
@@ -0,0 +1,189 @@
+package arabictext
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// tatweel is U+0640 KASHIDA (also called tatweel), the glyph justification
+// inserts to stretch Arabic text instead of expanding spaces.
+const tatweel = 0x0640
+
+// formLookup is a reverse lookup entry for a presentation-form glyph: the
+// base letter it came from and which of the four contextual forms it is.
+type formLookup struct {
+	base rune
+	form FormType
+}
+
+// presentationForms maps every contextual glyph produced by
+// ReshapeWithOptions back to its base letter and form, for Justify to
+// inspect already-reshaped text.
+var presentationForms = buildPresentationForms()
+
+// ligatureGlyphs holds every glyph ReshapeWithOptions can emit for a
+// Lam-Alef ligature; kashida is never inserted next to one (rule: "never
+// adjacent to Lam-Alef").
+var ligatureGlyphs = buildLigatureGlyphs()
+
+func buildPresentationForms() map[rune]formLookup {
+	m := make(map[rune]formLookup, len(arabicForms)*4)
+	for base, forms := range arabicForms {
+		for form, r := range forms {
+			if _, exists := m[r]; !exists {
+				m[r] = formLookup{base: base, form: FormType(form)}
+			}
+		}
+	}
+	return m
+}
+
+func buildLigatureGlyphs() map[rune]bool {
+	m := make(map[rune]bool, len(lamAlefLigatures)*4)
+	for _, forms := range lamAlefLigatures {
+		for _, r := range forms {
+			m[r] = true
+		}
+	}
+	return m
+}
+
+func isSeenFamily(base rune) bool {
+	switch base {
+	case 'س', 'ش', 'ص', 'ض':
+		return true
+	}
+	return false
+}
+
+func isHaOrYa(base rune) bool {
+	switch base {
+	case 'ه', 'ي', 'ى':
+		return true
+	}
+	return false
+}
+
+// kashidaPriority reports the priority of inserting a kashida right after
+// cur (between cur and next), lowest number first: (1) after a Kaf/Lam
+// medial form, (2) after a Seen-family medial/initial form, (3) right
+// before a final Ha/Ya form. ok is false if this junction isn't a valid
+// kashida position at all.
+func kashidaPriority(cur, next formLookup) (priority int, ok bool) {
+	switch {
+	case cur.form == Medial && (cur.base == 'ك' || cur.base == 'ل'):
+		return 1, true
+	case (cur.form == Medial || cur.form == Initial) && isSeenFamily(cur.base):
+		return 2, true
+	case next.form == Final && isHaOrYa(next.base):
+		return 3, true
+	default:
+		return 0, false
+	}
+}
+
+// kashidaPosition is a candidate index (into the rune slice) after which a
+// tatweel may be inserted, and the priority tier it belongs to.
+type kashidaPosition struct {
+	index    int
+	priority int
+}
+
+// kashidaPositions finds every valid kashida insertion point in already
+// reshaped text (see Reshape/ReshapeWithOptions), ordered by priority.
+// A position between rune i and rune i+1 is only a candidate when both
+// are connected Arabic letters in their presentation forms - this
+// automatically excludes non-connecting letters (their form is never
+// Medial/Initial), word boundaries and numeric runs (space/digits/
+// punctuation have no entry in presentationForms), and anything next to a
+// Lam-Alef ligature (excluded explicitly).
+func kashidaPositions(runes []rune) []kashidaPosition {
+	var positions []kashidaPosition
+	for i := 0; i < len(runes)-1; i++ {
+		if ligatureGlyphs[runes[i]] || ligatureGlyphs[runes[i+1]] {
+			continue
+		}
+		cur, curOK := presentationForms[runes[i]]
+		if !curOK || cur.form != Medial && cur.form != Initial {
+			continue
+		}
+		next, nextOK := presentationForms[runes[i+1]]
+		if !nextOK {
+			continue
+		}
+		if priority, ok := kashidaPriority(cur, next); ok {
+			positions = append(positions, kashidaPosition{index: i, priority: priority})
+		}
+	}
+	sort.SliceStable(positions, func(a, b int) bool { return positions[a].priority < positions[b].priority })
+	return positions
+}
+
+// Justify stretches text to targetWidth by inserting U+0640 TATWEEL
+// between eligible letter pairs - the kashida technique proper Arabic
+// typesetting uses instead of widening spaces. text must already be
+// reshaped (see Reshape/ReshapeWithOptions) since Justify identifies
+// insertion points from presentation forms, and must be called before
+// Reverse so positions are still in logical order.
+//
+// measure reports the display width of a single rune; Justify uses it to
+// convert the required stretch (targetWidth - currentWidth) into a number
+// of tatweels, then fills candidate positions highest-priority first
+// (after a Kaf/Lam medial, then a Seen-family letter, then before a final
+// Ha/Ya), falling back to a lower tier only once the one above is
+// exhausted. If there aren't enough candidates to absorb the full
+// stretch, Justify inserts as many as it found and returns - it never
+// stretches past the available positions.
+func Justify(text string, currentWidth, targetWidth float64, measure func(rune) float64) string {
+	stretch := targetWidth - currentWidth
+	if text == "" || stretch <= 0 {
+		return text
+	}
+
+	tatweelWidth := measure(tatweel)
+	if tatweelWidth <= 0 {
+		return text
+	}
+
+	needed := int(math.Ceil(stretch / tatweelWidth))
+	if needed <= 0 {
+		return text
+	}
+
+	runes := []rune(text)
+	positions := kashidaPositions(runes)
+	if len(positions) > needed {
+		positions = positions[:needed]
+	}
+
+	insertAfter := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		insertAfter[p.index] = true
+	}
+
+	var b strings.Builder
+	for i, r := range runes {
+		b.WriteRune(r)
+		if insertAfter[i] {
+			b.WriteRune(tatweel)
+		}
+	}
+	return b.String()
+}
+
+// JustifyText reshapes, kashida-justifies to targetWidth, and reorders
+// Arabic text for RTL display - Justify slotted into the same
+// Reshape-then-Reverse pipeline ProcessSimple uses. currentWidth should
+// be the display width of the unjustified line (e.g. from
+// MeasureTextWidth on Process's output), so Justify knows how much
+// stretch is needed. Use this to justify one line of wrapped RTL text;
+// non-Arabic text is returned unchanged since there's nothing to stretch
+// with kashida.
+func JustifyText(text string, currentWidth, targetWidth float64, measure func(rune) float64) string {
+	if !HasArabic(text) {
+		return text
+	}
+	justified := Justify(Reshape(text), currentWidth, targetWidth, measure)
+	return MirrorBrackets(Reverse(justified))
+}
@@ -0,0 +1,264 @@
+package arabictext
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Shaper applies the same contextual letter shaping as ReshapeWithOptions,
+// but through a bounded lookahead window instead of materializing the
+// whole string into a []rune - useful when generating PDF content streams
+// with thousands of short text objects, where Reshape's per-call slice
+// allocation adds up.
+//
+// The window only looks up to 3 runes past the current one - enough to
+// see past a doubled consonant's shadda+vowel pair to the next base
+// letter (e.g. Dal+fatha+shadda+Ra), or past a Lam-Alef ligature's Alef
+// with one mark in between - so, unlike Reshape, a Shaper can lose track
+// of connectivity if more than two marks are stacked between two base
+// letters. That's rare in practice; Reshape remains the fully general
+// path.
+type Shaper struct {
+	opts ShapeOptions
+}
+
+// NewShaper returns a Shaper that applies opts to every string it shapes.
+func NewShaper(opts ShapeOptions) *Shaper {
+	return &Shaper{opts: opts}
+}
+
+// runeWindow is the lookahead/lookbehind a Shaper needs: peek(0) is the
+// current rune, peek(1) and peek(2) look one and two runes ahead; advance
+// consumes n runes, sliding the window forward.
+type runeWindow interface {
+	peek(offset int) (rune, bool)
+	advance(n int)
+}
+
+// sliceWindow is a runeWindow over a caller-owned []rune, used by
+// ShapeInto - no allocation beyond the window itself.
+type sliceWindow struct {
+	runes []rune
+	pos   int
+}
+
+func (w *sliceWindow) peek(offset int) (rune, bool) {
+	i := w.pos + offset
+	if i < 0 || i >= len(w.runes) {
+		return 0, false
+	}
+	return w.runes[i], true
+}
+
+func (w *sliceWindow) advance(n int) {
+	w.pos += n
+}
+
+// stringWindow is a runeWindow directly over a string's UTF-8 bytes, used
+// by WriteString so it never builds a []rune(text) copy of the whole
+// input. peek re-decodes from the current byte position each time, which
+// costs at most 2 extra rune decodes - cheap, and avoids keeping any
+// buffer larger than the window itself.
+type stringWindow struct {
+	s   string
+	pos int // byte offset of the rune at peek(0)
+}
+
+func (w *stringWindow) peek(offset int) (rune, bool) {
+	p := w.pos
+	var r rune
+	for i := 0; i <= offset; i++ {
+		if p >= len(w.s) {
+			return 0, false
+		}
+		var size int
+		r, size = utf8.DecodeRuneInString(w.s[p:])
+		p += size
+	}
+	return r, true
+}
+
+func (w *stringWindow) advance(n int) {
+	for i := 0; i < n; i++ {
+		if w.pos >= len(w.s) {
+			return
+		}
+		_, size := utf8.DecodeRuneInString(w.s[w.pos:])
+		w.pos += size
+	}
+}
+
+// boundedNextBase looks ahead at most 3 runes from start for the next
+// rune that isn't harakat, returning how far ahead it is (1-3). It gives
+// up rather than looking further, per the window's bound.
+func boundedNextBase(w runeWindow, start int) (r rune, delta int, ok bool) {
+	for delta = start; delta <= start+2; delta++ {
+		r, ok = w.peek(delta)
+		if !ok {
+			return 0, 0, false
+		}
+		if !isHarakat(r) {
+			return r, delta, true
+		}
+	}
+	return 0, 0, false
+}
+
+// shapeStream runs the contextual shaping algorithm over w, calling emit
+// once per output rune in order. emit returns false to stop early (e.g.
+// on a write error), in which case shapeStream returns immediately.
+func shapeStream(opts ShapeOptions, w runeWindow, emit func(rune) bool) {
+	prevConnects := false
+
+	for {
+		r, ok := w.peek(0)
+		if !ok {
+			return
+		}
+
+		if r == 0x0640 && !opts.Tatweel {
+			w.advance(1)
+			continue
+		}
+
+		if isHarakat(r) {
+			if opts.Tashkeel {
+				if !emit(r) {
+					return
+				}
+			}
+			w.advance(1)
+			continue
+		}
+
+		if !IsArabic(r) {
+			if !emit(r) {
+				return
+			}
+			prevConnects = false
+			w.advance(1)
+			continue
+		}
+
+		if opts.Ligatures && r == 'ل' {
+			if nb, delta, ok := boundedNextBase(w, 1); ok {
+				if forms, ok := lamAlefLigatures[nb]; ok {
+					if prevConnects {
+						if !emit(forms[Final]) {
+							return
+						}
+					} else {
+						if !emit(forms[Isolated]) {
+							return
+						}
+					}
+					if opts.Tashkeel {
+						for k := 1; k < delta; k++ {
+							if mk, ok := w.peek(k); ok {
+								if !emit(mk) {
+									return
+								}
+							}
+						}
+					}
+					w.advance(delta + 1)
+					prevConnects = false
+					continue
+				}
+			}
+		}
+
+		forms, hasForms := arabicForms[r]
+		if !hasForms {
+			if !emit(r) {
+				return
+			}
+			prevConnects = false
+			w.advance(1)
+			continue
+		}
+
+		nb, _, nbOk := boundedNextBase(w, 1)
+		nextConnects := nbOk && IsArabic(nb)
+
+		var formType FormType
+		isNonConnecting := IsNonConnecting(r)
+		switch {
+		case isNonConnecting && prevConnects:
+			formType = Final
+		case isNonConnecting:
+			formType = Isolated
+		case prevConnects && nextConnects:
+			formType = Medial
+		case prevConnects:
+			formType = Final
+		case nextConnects:
+			formType = Initial
+		default:
+			formType = Isolated
+		}
+
+		if opts.Tashkeel {
+			if a, aOk := w.peek(1); aOk {
+				if b, bOk := w.peek(2); bOk {
+					if lig, ok := shaddaVowelPair(a, b); ok {
+						if !emit(forms[formType]) {
+							return
+						}
+						glyph := lig.isolated
+						if (formType == Medial || formType == Initial) && lig.medial != 0 {
+							glyph = lig.medial
+						}
+						if !emit(glyph) {
+							return
+						}
+						w.advance(3)
+						prevConnects = !isNonConnecting
+						continue
+					}
+				}
+			}
+		}
+
+		if !emit(forms[formType]) {
+			return
+		}
+		prevConnects = !isNonConnecting
+		w.advance(1)
+	}
+}
+
+// ShapeInto shapes src into dst and returns the number of runes written.
+// dst must be at least len(src) long - shaping never produces more runes
+// than it consumes (ligatures and shadda+vowel composition only ever
+// shrink the output). Unlike ReshapeWithOptions, it writes directly into
+// a caller-supplied buffer instead of allocating a new one.
+func (s *Shaper) ShapeInto(dst []rune, src []rune) int {
+	n := 0
+	w := &sliceWindow{runes: src}
+	shapeStream(s.opts, w, func(r rune) bool {
+		dst[n] = r
+		n++
+		return true
+	})
+	return n
+}
+
+// WriteString shapes text and writes the result directly to w, without
+// ever allocating a []rune of the whole input - it streams through a
+// bounded lookahead window instead (see Shaper). n is the number of
+// bytes written to w; err is the first error w returns, if any.
+func (s *Shaper) WriteString(w io.Writer, text string) (n int, err error) {
+	var buf [utf8.UTFMax]byte
+	shapeStream(s.opts, &stringWindow{s: text}, func(r rune) bool {
+		size := utf8.EncodeRune(buf[:], r)
+		written, werr := w.Write(buf[:size])
+		n += written
+		if werr != nil {
+			err = werr
+			return false
+		}
+		return true
+	})
+	return n, err
+}
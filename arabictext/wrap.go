@@ -0,0 +1,206 @@
+package arabictext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WrapShaped greedily packs text into lines no wider than maxWidth. It
+// tokenizes on Unicode whitespace plus ZWSP (U+200B) and ZWNJ (U+200C),
+// shapes and measures each token exactly once via measure, then packs
+// tokens into lines - rather than growing a line one rune at a time and
+// reshaping/remeasuring the whole prefix on every rune, which is O(n^2)
+// and, worse, can break mid-word: Arabic contextual shaping means a
+// word's rendered width isn't the sum of its letters' widths (see
+// Reshape), so a mid-word prefix measures wrong and a mid-word break
+// renders the wrong glyphs.
+//
+// Word boundaries (spaces) always break shaping context, so shaping a
+// token on its own gives it the same width it has inside the finished
+// line - WrapShaped relies on that to measure each token exactly once
+// instead of re-measuring the whole growing line on every candidate break.
+//
+// Returned lines are plain, logical-order substrings of text - not yet
+// shaped or reordered for display, exactly like the lines the previous
+// character-by-character wrapper produced - so callers still run them
+// through Process/JustifyText themselves. That full bidi pass is what
+// actually reverses an RTL line and seats embedded Latin/numeric runs and
+// punctuation on the correct side; redoing that token-by-token here would
+// just reinvent what the Bidi algorithm already gets right on a whole
+// line.
+//
+// A single token wider than maxWidth on its own - too long to fit even
+// alone on a line - falls back to splitting it at grapheme-cluster
+// boundaries (a base letter plus any trailing harakat) instead of an
+// arbitrary rune cut, preferring a soft hyphen (U+00AD) as the break point
+// when the token has one within reach, same as splitToFit's own doc comment
+// describes.
+//
+// "\n" is always a forced break, regardless of width: each line it
+// separates is wrapped independently, so a blank line in the input
+// produces an empty line in the output rather than collapsing away.
+func WrapShaped(text string, measure func(string) float64, maxWidth float64) []string {
+	if text == "" {
+		return nil
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		paragraphLines := wrapParagraph(paragraph, measure, maxWidth)
+		if len(paragraphLines) == 0 {
+			paragraphLines = []string{""}
+		}
+		lines = append(lines, paragraphLines...)
+	}
+	return lines
+}
+
+// wrapParagraph wraps a single "\n"-free line of text; see WrapShaped.
+func wrapParagraph(text string, measure func(string) float64, maxWidth float64) []string {
+	tokens := tokenize(text)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	spaceWidth := measure(" ")
+
+	var lines []string
+	var cur []string
+	var curWidth float64
+
+	flush := func() {
+		if len(cur) > 0 {
+			lines = append(lines, strings.Join(cur, " "))
+			cur = nil
+			curWidth = 0
+		}
+	}
+
+	for _, tok := range tokens {
+		w := measureShaped(tok, measure)
+
+		if w > maxWidth {
+			flush()
+			lines = append(lines, splitToFit(tok, measure, maxWidth)...)
+			continue
+		}
+
+		extra := w
+		if len(cur) > 0 {
+			extra += spaceWidth
+		}
+		if len(cur) > 0 && curWidth+extra > maxWidth {
+			flush()
+			extra = w
+		}
+
+		cur = append(cur, tok)
+		curWidth += extra
+	}
+	flush()
+
+	return lines
+}
+
+// measureShaped returns the rendered width of tok after contextual
+// shaping, without reordering it for display - reversal only matters once
+// tok is embedded in its final line (see WrapShaped).
+func measureShaped(tok string, measure func(string) float64) float64 {
+	if HasArabic(tok) {
+		return measure(Reshape(tok))
+	}
+	return measure(tok)
+}
+
+// noBreakSpace is U+00A0: unlike every other Unicode whitespace character,
+// its purpose is to glue the tokens on either side of it into one
+// unbreakable visual unit (e.g. a number and its unit), so tokenize must
+// not split on it.
+const noBreakSpace = '\u00a0'
+
+// tokenize splits text on Unicode whitespace (except U+00A0, see
+// noBreakSpace) plus ZWSP (U+200B) and ZWNJ (U+200C); runs of separators
+// collapse to a single break and are discarded - WrapShaped rejoins
+// surviving tokens with a single space. Arabic tatweel (U+0640) is never a
+// break point - it isn't whitespace, so it already stays attached to its
+// word like any other letter.
+func tokenize(text string) []string {
+	var tokens []string
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			tokens = append(tokens, b.String())
+			b.Reset()
+		}
+	}
+	for _, r := range text {
+		if r == noBreakSpace {
+			b.WriteRune(r)
+			continue
+		}
+		if unicode.IsSpace(r) || r == 0x200B || r == 0x200C {
+			flush()
+			continue
+		}
+		b.WriteRune(r)
+	}
+	flush()
+	return tokens
+}
+
+// clusterEnd returns the index right after the grapheme cluster starting
+// at runes[i]: the base rune plus any harakat immediately following it, so
+// a hard split never separates a letter from its vowel mark.
+func clusterEnd(runes []rune, i int) int {
+	i++
+	for i < len(runes) && isHarakat(runes[i]) {
+		i++
+	}
+	return i
+}
+
+// softHyphen is U+00AD: an invisible, optional break point a caller can
+// embed inside a long word (e.g. "super­califragilistic"). splitToFit
+// never prints it unless it actually breaks there, in which case it's
+// replaced by a visible "-".
+const softHyphen = '­'
+
+// splitToFit hard-splits tok - a single token wider than maxWidth even on
+// its own line - into smaller pieces, growing one grapheme cluster at a
+// time until the next one wouldn't fit. If a soft hyphen falls within the
+// piece that's being grown, the piece breaks there (showing a visible "-")
+// instead of at an arbitrary grapheme-cluster boundary, same as a real
+// typesetter prefers a word's marked hyphenation points.
+func splitToFit(tok string, measure func(string) float64, maxWidth float64) []string {
+	runes := []rune(tok)
+	var pieces []string
+	start := 0
+	for start < len(runes) {
+		end := clusterEnd(runes, start)
+		softBreak := -1 // index of the last soft hyphen seen within [start, end)
+		for end < len(runes) {
+			next := clusterEnd(runes, end)
+			if measureShaped(stripSoftHyphens(string(runes[start:next])), measure) > maxWidth {
+				break
+			}
+			if runes[end] == softHyphen {
+				softBreak = end
+			}
+			end = next
+		}
+		if softBreak >= 0 && end < len(runes) {
+			pieces = append(pieces, stripSoftHyphens(string(runes[start:softBreak]))+"-")
+			start = softBreak + 1
+			continue
+		}
+		pieces = append(pieces, stripSoftHyphens(string(runes[start:end])))
+		start = end
+	}
+	return pieces
+}
+
+// stripSoftHyphens removes every softHyphen from s - used on a piece that
+// didn't need to break at one, since a soft hyphen never prints on its own.
+func stripSoftHyphens(s string) string {
+	return strings.ReplaceAll(s, string(softHyphen), "")
+}
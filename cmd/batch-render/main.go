@@ -0,0 +1,122 @@
+// Batch Render - renders many invoices concurrently via pkg/batch.
+//
+// Where cmd/generator renders one invoice per run, this command reads a
+// JSON-lines file of InvoiceData records (one per line) and renders them
+// all through a worker pool, writing one PDF per invoice plus a manifest
+// for auditing the run - see pkg/batch's package doc comment.
+//
+// Usage:
+//
+//	Set environment variables:
+//	  OUTPUT_DIR  - Directory PDFs and manifest.json are written to
+//	                (default: current directory)
+//	  FONT_DIR    - Directory containing Amiri fonts (default: fonts)
+//	  DATA_FILE   - Path to a JSON-lines file of InvoiceData records
+//	                (default: invoices.jsonl)
+//	  WORKERS     - Number of concurrent render workers (default: 4)
+//
+// Example:
+//
+//	DATA_FILE=invoices.jsonl OUTPUT_DIR=out ./batch-render
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"bill-generator/pkg/batch"
+)
+
+func main() {
+	if err := run(loadConfig()); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// appConfig holds application configuration from environment variables.
+type appConfig struct {
+	OutputDir string
+	FontDir   string
+	DataFile  string
+	Workers   int
+}
+
+// loadConfig reads configuration from environment variables.
+func loadConfig() appConfig {
+	return appConfig{
+		OutputDir: getEnv("OUTPUT_DIR", "."),
+		FontDir:   getEnv("FONT_DIR", "fonts"),
+		DataFile:  getEnv("DATA_FILE", "invoices.jsonl"),
+		Workers:   getEnvInt("WORKERS", 0),
+	}
+}
+
+// getEnv returns an environment variable value or a default.
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvInt returns an environment variable parsed as an int, or a
+// default if it's unset or not a valid integer.
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return n
+}
+
+// run reads config.DataFile, renders every invoice it holds, and writes
+// the results (and a manifest) to config.OutputDir.
+func run(config appConfig) error {
+	f, err := os.Open(config.DataFile)
+	if err != nil {
+		return fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer f.Close()
+
+	results, err := batch.Run(batch.NewJSONLinesSource(f), batch.Config{
+		FontDir: config.FontDir,
+		Workers: config.Workers,
+		OnProgress: func(completed int, result batch.Result) {
+			status := "ok"
+			if result.Err != nil {
+				status = result.Err.Error()
+			}
+			fmt.Printf("[%d] seq=%d %s\n", completed, result.Seq, status)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render batch: %w", err)
+	}
+
+	if err := batch.WriteDir(results, config.OutputDir, batch.DefaultName); err != nil {
+		return fmt.Errorf("failed to write PDFs: %w", err)
+	}
+
+	manifestPath := config.OutputDir + "/manifest.json"
+	if err := batch.WriteManifest(results, manifestPath, batch.DefaultName); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	failures := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failures++
+		}
+	}
+	fmt.Printf("rendered %d/%d invoices, manifest written to %s\n", len(results)-failures, len(results), manifestPath)
+	if failures > 0 {
+		return fmt.Errorf("%d of %d invoices failed to render (see %s)", failures, len(results), manifestPath)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Bill Server - RPC front end for bill-generator
+//
+// The request (see proto/invoice.proto) was a gRPC BillService.RenderInvoice
+// RPC streaming a rendered PDF back to the caller, so other services could
+// request an invoice without shelling out to cmd/generator. Generating that
+// service needs google.golang.org/grpc and google.golang.org/protobuf,
+// neither of which is in this module's dependency set, and this environment
+// has no network access to go get them or run protoc.
+//
+// Until that dependency is available, this command exposes the same
+// RenderInvoice method as a real listener over the standard library's
+// net/rpc instead: a genuine RPC server a caller can dial and get a PDF
+// back from, just not gRPC-wire-compatible - callers need a Go net/rpc
+// client (or a gob-speaking equivalent), not a .proto-generated one. It's a
+// stand-in for loader.GRPCSource/FromProto, which are the pieces still
+// missing; the rest of the pipeline it sits on top of
+// (loader.ParseJSON -> pdf.Generator) is the real thing.
+//
+// Usage:
+//
+//	Set environment variables:
+//	  BIND_ADDR      - Address net/rpc listens on (default: :8081)
+//	  FONT_DIR       - Directory containing Amiri fonts (default: fonts)
+//	  RENDER_ENGINE  - Which backend renders the PDF (default: native); see
+//	                   cmd/generator's doc comment for the available values.
+//	  CURRENCY_SYMBOL - Currency symbol the "component" engine renders next
+//	                   to amounts (default: none).
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+
+	"bill-generator/pkg/component"
+	"bill-generator/pkg/htmlrender"
+	"bill-generator/pkg/loader"
+	"bill-generator/pkg/models"
+	"bill-generator/pkg/pdf"
+)
+
+func main() {
+	if err := run(loadConfig()); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// serverConfig holds application configuration from environment variables.
+type serverConfig struct {
+	BindAddr       string
+	FontDir        string
+	RenderEngine   string
+	CurrencySymbol string
+}
+
+// loadConfig reads configuration from environment variables.
+func loadConfig() serverConfig {
+	return serverConfig{
+		BindAddr:       getEnv("BIND_ADDR", ":8081"),
+		FontDir:        getEnv("FONT_DIR", "fonts"),
+		RenderEngine:   getEnv("RENDER_ENGINE", "native"),
+		CurrencySymbol: getEnv("CURRENCY_SYMBOL", ""),
+	}
+}
+
+// getEnv returns an environment variable value or a default.
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// RenderInvoiceRequest carries the invoice data to render, as the same JSON
+// document loader.ParseJSON accepts from a DATA_FILE in cmd/generator.
+type RenderInvoiceRequest struct {
+	InvoiceJSON []byte
+}
+
+// RenderInvoiceReply carries the rendered PDF. net/rpc is unary-only, so
+// unlike the originally requested "stream PdfChunk", the whole PDF comes
+// back in one reply.
+type RenderInvoiceReply struct {
+	PDF []byte
+}
+
+// BillService exposes RenderInvoice over net/rpc.
+type BillService struct {
+	config serverConfig
+}
+
+// RenderInvoice parses req.InvoiceJSON and renders it to PDF bytes, per the
+// net/rpc method signature (exported method, two args, pointer reply,
+// error return).
+func (s *BillService) RenderInvoice(req *RenderInvoiceRequest, reply *RenderInvoiceReply) error {
+	invoice, err := loader.ParseJSON(req.InvoiceJSON)
+	if err != nil {
+		return fmt.Errorf("failed to parse invoice data: %w", err)
+	}
+
+	pdfBytes, err := renderInvoice(invoice, s.config)
+	if err != nil {
+		return fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	reply.PDF = pdfBytes
+	return nil
+}
+
+// renderInvoice renders invoice to PDF bytes using the engine named by
+// config.RenderEngine - see cmd/generator's renderInvoice, which this
+// mirrors.
+func renderInvoice(invoice models.Invoice, config serverConfig) ([]byte, error) {
+	generator := pdf.NewGenerator(config.FontDir)
+
+	if config.RenderEngine == "component" {
+		cfg := models.Config{
+			VATPercentage:  invoice.VATPercentage,
+			CurrencySymbol: config.CurrencySymbol,
+			English:        !invoice.IsRTL,
+			PageSize:       invoice.PageSize,
+		}
+		return htmlrender.NewDefaultRenderer(config.FontDir).RenderPDF(invoice, cfg)
+	}
+
+	engine := component.EngineNative
+	if config.RenderEngine == "html" {
+		engine = component.EngineHTML
+	}
+	return component.RenderPDF(invoice, engine, generator.GenerateBytes, nil)
+}
+
+// run registers BillService and serves RenderInvoice requests on
+// config.BindAddr until the listener fails or the process is killed.
+func run(config serverConfig) error {
+	service := &BillService{config: config}
+	server := rpc.NewServer()
+	if err := server.Register(service); err != nil {
+		return fmt.Errorf("failed to register BillService: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", config.BindAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", config.BindAddr, err)
+	}
+	defer listener.Close()
+
+	fmt.Printf("bill-server: BillService.RenderInvoice listening on %s (net/rpc, not gRPC-wire-compatible)\n", listener.Addr())
+	server.Accept(listener)
+	return nil
+}
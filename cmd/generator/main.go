@@ -6,20 +6,42 @@
 // Usage:
 //
 //	Set environment variables:
-//	  OUTPUT_DIR  - Directory for output PDF (default: current directory)
-//	  FONT_DIR    - Directory containing Amiri fonts (default: fonts)
-//	  DATA_FILE   - Path to invoice JSON file (default: invoice_data.json)
+//	  OUTPUT_DIR     - Directory for output PDF (default: current directory)
+//	  FONT_DIR       - Directory containing Amiri fonts (default: fonts)
+//	  DATA_FILE      - Path to invoice data file (default: invoice_data.json).
+//	                   A ".csv" extension loads via loader.CSVSource (with a
+//	                   sidecar DATA_FILE+".meta.json"); anything else loads as
+//	                   JSON via loader.JSONFileSource. See loader.SourceForFile.
+//	  RENDER_ENGINE  - Which backend renders the PDF (default: native):
+//	                     native    - pkg/pdf.Generator, manual SetXY/Cell layout.
+//	                     html      - pkg/htmlpdf, an html/template document
+//	                                 converted to PDF by wkhtmltopdf.
+//	                     component - pkg/htmlrender, an html/template document
+//	                                 laid out by pkg/component's Page/Row/Col
+//	                                 grid engine - a pure-Go alternative to html.
+//	                   See pkg/component.RenderPDF, which dispatches the first
+//	                   two; "component" is dispatched here directly since
+//	                   pkg/component can't import pkg/htmlrender without an
+//	                   import cycle (pkg/htmlrender already imports
+//	                   pkg/component).
+//	  CURRENCY_SYMBOL - Currency symbol the "component" engine's templates
+//	                   render next to amounts (default: none).
 //
 // Example:
 //
 //	DATA_FILE=invoice_en.json ./bill-generator
+//	RENDER_ENGINE=component DATA_FILE=invoice_en.json ./bill-generator
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"bill-generator/pkg/component"
+	"bill-generator/pkg/htmlrender"
 	"bill-generator/pkg/loader"
+	"bill-generator/pkg/models"
 	"bill-generator/pkg/pdf"
 )
 
@@ -34,17 +56,21 @@ func main() {
 
 // appConfig holds application configuration from environment variables.
 type appConfig struct {
-	OutputDir string
-	FontDir   string
-	DataFile  string
+	OutputDir      string
+	FontDir        string
+	DataFile       string
+	RenderEngine   string
+	CurrencySymbol string
 }
 
 // loadConfig reads configuration from environment variables.
 func loadConfig() appConfig {
 	config := appConfig{
-		OutputDir: getEnv("OUTPUT_DIR", "."),
-		FontDir:   getEnv("FONT_DIR", "fonts"),
-		DataFile:  getEnv("DATA_FILE", "invoice_data.json"),
+		OutputDir:      getEnv("OUTPUT_DIR", "."),
+		FontDir:        getEnv("FONT_DIR", "fonts"),
+		DataFile:       getEnv("DATA_FILE", "invoice_data.json"),
+		RenderEngine:   getEnv("RENDER_ENGINE", "native"),
+		CurrencySymbol: getEnv("CURRENCY_SYMBOL", ""),
 	}
 	return config
 }
@@ -59,19 +85,21 @@ func getEnv(key, defaultValue string) string {
 
 // run executes the main invoice generation logic.
 func run(config appConfig) error {
-	// Load invoice data from JSON
-	invoice, err := loader.LoadFromJSON(config.DataFile)
+	// Load invoice data, picking JSON or CSV based on DATA_FILE's extension
+	invoice, err := loader.SourceForFile(config.DataFile).Load(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to load invoice data: %w", err)
 	}
 
 	// Generate PDF
 	outputFile := config.OutputDir + "/invoice_output.pdf"
-	generator := pdf.NewGenerator(config.FontDir)
-
-	if err := generator.Generate(invoice, outputFile); err != nil {
+	pdfBytes, err := renderInvoice(invoice, config)
+	if err != nil {
 		return fmt.Errorf("failed to generate PDF: %w", err)
 	}
+	if err := os.WriteFile(outputFile, pdfBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
 
 	// Verify output
 	if err := verifyOutput(outputFile); err != nil {
@@ -82,6 +110,30 @@ func run(config appConfig) error {
 	return nil
 }
 
+// renderInvoice renders invoice to PDF bytes using the engine named by
+// config.RenderEngine. "native" and "html" are dispatched through
+// component.RenderPDF; "component" goes straight to pkg/htmlrender (see the
+// package doc comment for why it can't also go through component.RenderPDF).
+func renderInvoice(invoice models.Invoice, config appConfig) ([]byte, error) {
+	generator := pdf.NewGenerator(config.FontDir)
+
+	if config.RenderEngine == "component" {
+		cfg := models.Config{
+			VATPercentage:  invoice.VATPercentage,
+			CurrencySymbol: config.CurrencySymbol,
+			English:        !invoice.IsRTL,
+			PageSize:       invoice.PageSize,
+		}
+		return htmlrender.NewDefaultRenderer(config.FontDir).RenderPDF(invoice, cfg)
+	}
+
+	engine := component.EngineNative
+	if config.RenderEngine == "html" {
+		engine = component.EngineHTML
+	}
+	return component.RenderPDF(invoice, engine, generator.GenerateBytes, nil)
+}
+
 // verifyOutput checks that the PDF was generated correctly.
 func verifyOutput(filename string) error {
 	info, err := os.Stat(filename)
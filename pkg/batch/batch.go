@@ -0,0 +1,136 @@
+// Package batch renders many invoices concurrently with a worker pool and
+// either merges the results into one PDF or writes one PDF per invoice to
+// a directory.
+//
+// pkg/pdf.Generator renders one invoice per Draw call against its own
+// gopdf.GoPdf instance, which is fine for a single invoice but forces a
+// POS/ERP integration generating thousands per run to serialize
+// everything. batch.Run instead hands each Source record to a pool of
+// workers, each of which owns its own Generator (and so its own
+// gopdf.GoPdf) to avoid contention, and reassembles the results in the
+// order they were read regardless of which worker finished first or
+// which invoice failed.
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"bill-generator/pkg/loader"
+	"bill-generator/pkg/models"
+	"bill-generator/pkg/pdf"
+)
+
+// Result is the outcome of rendering one invoice read from a Source. Seq
+// is the zero-based order it was read in, stable regardless of worker
+// scheduling.
+type Result struct {
+	Seq      int
+	Invoice  models.InvoiceData
+	PDF      []byte
+	Duration time.Duration
+	Err      error
+}
+
+// Config configures a Run.
+type Config struct {
+	// FontDir and GeneratorOptions are passed to pdf.NewGenerator to build
+	// each worker's Generator.
+	FontDir          string
+	GeneratorOptions []pdf.Option
+
+	// Workers is the number of concurrent render workers. Defaults to 4
+	// if zero or negative.
+	Workers int
+
+	// OnProgress, if set, is called after every invoice finishes
+	// rendering (success or failure), from a single goroutine, once per
+	// completed invoice. completed is a running count, not Result.Seq, so
+	// it always reaches len(results) by the time Run returns.
+	OnProgress func(completed int, result Result)
+}
+
+// defaultWorkers is used when Config.Workers is unset.
+const defaultWorkers = 4
+
+// Run reads every invoice Source yields, renders them concurrently across
+// cfg.Workers workers, and returns one Result per invoice in Source order.
+// A render error is isolated to that invoice's Result.Err; it does not
+// stop the rest of the batch. Run only returns a non-nil error itself if
+// src fails outside of EOF.
+func Run(src Source, cfg Config) ([]Result, error) {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	type job struct {
+		seq  int
+		data models.InvoiceData
+	}
+
+	jobs := make(chan job)
+	results := make(chan Result)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			generator := pdf.NewGenerator(cfg.FontDir, cfg.GeneratorOptions...)
+			for j := range jobs {
+				results <- render(generator, j.seq, j.data)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var readErr error
+	go func() {
+		defer close(jobs)
+		for seq := 0; ; seq++ {
+			data, err := src.Next()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					readErr = err
+				}
+				return
+			}
+			jobs <- job{seq: seq, data: data}
+		}
+	}()
+
+	collected := make([]Result, 0)
+	completed := 0
+	for result := range results {
+		collected = append(collected, result)
+		completed++
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(completed, result)
+		}
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("batch: read invoice source: %w", readErr)
+	}
+
+	sort.Slice(collected, func(i, j int) bool { return collected[i].Seq < collected[j].Seq })
+	return collected, nil
+}
+
+func render(generator *pdf.Generator, seq int, data models.InvoiceData) Result {
+	start := time.Now()
+	invoice := loader.BuildInvoice(data)
+	pdfBytes, err := generator.GenerateBytes(invoice)
+	duration := time.Since(start)
+	if err != nil {
+		err = fmt.Errorf("batch: render invoice %d: %w", seq, err)
+	}
+	return Result{Seq: seq, Invoice: data, PDF: pdfBytes, Duration: duration, Err: err}
+}
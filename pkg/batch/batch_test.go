@@ -0,0 +1,176 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bill-generator/pkg/models"
+)
+
+func testInvoiceData(number string) models.InvoiceData {
+	return models.InvoiceData{
+		Config: models.Config{VATPercentage: 15},
+		Invoice: models.InvoiceInput{
+			Title:         "Invoice",
+			InvoiceNumber: number,
+			StoreName:     "Test Store",
+		},
+		Products: []models.ProductInput{
+			{Name: "Widget", Quantity: 1, UnitPrice: 10},
+		},
+	}
+}
+
+// Fonts aren't available in this environment, so every render fails;
+// that's fine for exercising Run's ordering and error-isolation
+// guarantees without needing a real font file.
+func TestRun_IsolatesPerInvoiceErrorsAndPreservesOrder(t *testing.T) {
+	records := make([]models.InvoiceData, 10)
+	for i := range records {
+		records[i] = testInvoiceData(string(rune('A' + i)))
+	}
+
+	results, err := Run(NewSliceSource(records), Config{FontDir: "/nonexistent-fonts", Workers: 3})
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if len(results) != len(records) {
+		t.Fatalf("expected %d results, got %d", len(records), len(results))
+	}
+
+	for i, r := range results {
+		if r.Seq != i {
+			t.Errorf("result %d has Seq %d, want %d (results must come back in source order)", i, r.Seq, i)
+		}
+		if r.Invoice.Invoice.InvoiceNumber != records[i].Invoice.InvoiceNumber {
+			t.Errorf("result %d carries invoice %q, want %q", i, r.Invoice.Invoice.InvoiceNumber, records[i].Invoice.InvoiceNumber)
+		}
+		if r.Err == nil {
+			t.Errorf("result %d: expected a render error with no font directory available", i)
+		} else if !strings.Contains(r.Err.Error(), "batch: render invoice") {
+			t.Errorf("result %d: expected error to be wrapped with batch context, got %q", i, r.Err)
+		}
+	}
+}
+
+func TestRun_ReportsProgressForEveryInvoice(t *testing.T) {
+	records := []models.InvoiceData{testInvoiceData("1"), testInvoiceData("2"), testInvoiceData("3")}
+
+	var mu sync.Mutex
+	var seen []int
+	onProgress := func(completed int, result Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, completed)
+	}
+
+	results, err := Run(NewSliceSource(records), Config{FontDir: "/nonexistent-fonts", Workers: 2, OnProgress: onProgress})
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if len(seen) != len(results) {
+		t.Fatalf("expected OnProgress to be called once per invoice (%d times), got %d", len(results), len(seen))
+	}
+}
+
+func TestRun_DefaultsWorkerCount(t *testing.T) {
+	records := []models.InvoiceData{testInvoiceData("1")}
+	results, err := Run(NewSliceSource(records), Config{FontDir: "/nonexistent-fonts"})
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+}
+
+func TestJSONLinesSource(t *testing.T) {
+	input := strings.NewReader(`{"invoice":{"invoiceNumber":"1"}}
+
+{"invoice":{"invoiceNumber":"2"}}
+`)
+	src := NewJSONLinesSource(input)
+
+	first, err := src.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Invoice.InvoiceNumber != "1" {
+		t.Errorf("expected invoice 1, got %q", first.Invoice.InvoiceNumber)
+	}
+
+	second, err := src.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Invoice.InvoiceNumber != "2" {
+		t.Errorf("expected invoice 2, got %q", second.Invoice.InvoiceNumber)
+	}
+
+	if _, err := src.Next(); err == nil {
+		t.Error("expected io.EOF once the lines are exhausted")
+	}
+}
+
+func TestJSONLinesSource_InvalidJSON(t *testing.T) {
+	src := NewJSONLinesSource(strings.NewReader("not json\n"))
+	if _, err := src.Next(); err == nil {
+		t.Error("expected an error for a malformed JSON line")
+	}
+}
+
+func TestDefaultName(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Result
+		want string
+	}{
+		{"uses the invoice number", Result{Seq: 5, Invoice: testInvoiceData("INV-42")}, "INV-42.pdf"},
+		{"falls back to the sequence number when blank", Result{Seq: 5, Invoice: testInvoiceData("")}, "invoice-5.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultName(tt.r); got != tt.want {
+				t.Errorf("DefaultName(...) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	results := []Result{
+		{Seq: 0, Invoice: testInvoiceData("1"), PDF: []byte("pdf-bytes"), Duration: 5 * time.Millisecond},
+		{Seq: 1, Invoice: testInvoiceData("2"), Err: errors.New("fake render failure")},
+	}
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := WriteManifest(results, path, DefaultName); err != nil {
+		t.Fatalf("WriteManifest returned an error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var entries []ManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if len(entries) != len(results) {
+		t.Fatalf("expected %d manifest entries, got %d", len(results), len(entries))
+	}
+
+	if entries[0].File != "1.pdf" || entries[0].SizeBytes != len("pdf-bytes") || entries[0].SHA256 == "" {
+		t.Errorf("entries[0] = %+v, want File=1.pdf SizeBytes=%d non-empty SHA256", entries[0], len("pdf-bytes"))
+	}
+	if entries[1].Error == "" || entries[1].SHA256 != "" {
+		t.Errorf("entries[1] = %+v, want a non-empty Error and no SHA256 (render failed)", entries[1])
+	}
+}
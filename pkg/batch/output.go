@@ -0,0 +1,109 @@
+package batch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"bill-generator/pkg/pdf"
+
+	"github.com/signintech/gopdf"
+)
+
+// Merge concatenates every successfully rendered Result's PDF (skipping
+// any with a non-nil Err) into a single multi-page PDF, in Result order.
+// pageSize should match the PageSize the batch's Generators rendered
+// with (see Config.GeneratorOptions / pdf.WithPageSize); the merged
+// document is started at that size before each invoice's page is
+// imported and rescaled into it.
+func Merge(results []Result, pageSize pdf.PageSize) ([]byte, error) {
+	merged := gopdf.GoPdf{}
+	merged.Start(gopdf.Config{PageSize: gopdf.Rect{W: pageSize.Width, H: pageSize.Height}})
+
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		if err := merged.ImportPagesFromSource(r.PDF, "/MediaBox"); err != nil {
+			return nil, fmt.Errorf("batch: merge invoice %d: %w", r.Seq, err)
+		}
+	}
+
+	return merged.GetBytesPdfReturnErr()
+}
+
+// WriteDir writes every successfully rendered Result's PDF (skipping any
+// with a non-nil Err) to its own file under dir, named by the name
+// callback. The directory is created if it doesn't already exist.
+func WriteDir(results []Result, dir string, name func(Result) string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("batch: create output directory %s: %w", dir, err)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name(r))
+		if err := os.WriteFile(path, r.PDF, 0o644); err != nil {
+			return fmt.Errorf("batch: write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultName names a Result's output file after its invoice number
+// (falling back to its sequence number if blank), for use with WriteDir.
+func DefaultName(r Result) string {
+	number := r.Invoice.Invoice.InvoiceNumber
+	if number == "" {
+		return fmt.Sprintf("invoice-%d.pdf", r.Seq)
+	}
+	return number + ".pdf"
+}
+
+// ManifestEntry records one rendered invoice's output file and timing, for
+// regression testing and auditing a batch run.
+type ManifestEntry struct {
+	File       string `json:"file"`
+	Seq        int    `json:"seq"`
+	SizeBytes  int    `json:"sizeBytes"`
+	SHA256     string `json:"sha256,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// WriteManifest writes a JSON array of ManifestEntry, one per result
+// (including failed ones, so a manifest always accounts for every input),
+// named by the same name callback passed to WriteDir so the two line up.
+func WriteManifest(results []Result, path string, name func(Result) string) error {
+	entries := make([]ManifestEntry, len(results))
+	for i, r := range results {
+		entry := ManifestEntry{
+			File:       name(r),
+			Seq:        r.Seq,
+			SizeBytes:  len(r.PDF),
+			DurationMs: r.Duration.Milliseconds(),
+		}
+		if r.Err != nil {
+			entry.Error = r.Err.Error()
+		} else {
+			sum := sha256.Sum256(r.PDF)
+			entry.SHA256 = hex.EncodeToString(sum[:])
+		}
+		entries[i] = entry
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("batch: marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("batch: write manifest %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"bill-generator/pkg/models"
+)
+
+// Source yields InvoiceData records to render, in order, until it returns
+// io.EOF.
+type Source interface {
+	Next() (models.InvoiceData, error)
+}
+
+// NewJSONLinesSource reads one JSON-encoded InvoiceData per non-blank
+// line from r.
+func NewJSONLinesSource(r io.Reader) Source {
+	return &jsonLinesSource{scanner: bufio.NewScanner(r)}
+}
+
+type jsonLinesSource struct {
+	scanner *bufio.Scanner
+}
+
+func (s *jsonLinesSource) Next() (models.InvoiceData, error) {
+	for s.scanner.Scan() {
+		line := bytes.TrimSpace(s.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var data models.InvoiceData
+		if err := json.Unmarshal(line, &data); err != nil {
+			return models.InvoiceData{}, fmt.Errorf("batch: parse JSON line: %w", err)
+		}
+		return data, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return models.InvoiceData{}, fmt.Errorf("batch: read JSON lines: %w", err)
+	}
+	return models.InvoiceData{}, io.EOF
+}
+
+// NewChannelSource adapts a channel of InvoiceData into a Source, for
+// callers that already produce invoices on a channel (e.g. from a queue
+// consumer). The channel should be closed once no more invoices follow.
+func NewChannelSource(ch <-chan models.InvoiceData) Source {
+	return &channelSource{ch: ch}
+}
+
+type channelSource struct {
+	ch <-chan models.InvoiceData
+}
+
+func (s *channelSource) Next() (models.InvoiceData, error) {
+	data, ok := <-s.ch
+	if !ok {
+		return models.InvoiceData{}, io.EOF
+	}
+	return data, nil
+}
+
+// NewSliceSource adapts an in-memory slice of InvoiceData into a Source.
+func NewSliceSource(data []models.InvoiceData) Source {
+	return &sliceSource{data: data}
+}
+
+type sliceSource struct {
+	data []models.InvoiceData
+	pos  int
+}
+
+func (s *sliceSource) Next() (models.InvoiceData, error) {
+	if s.pos >= len(s.data) {
+		return models.InvoiceData{}, io.EOF
+	}
+	data := s.data[s.pos]
+	s.pos++
+	return data, nil
+}
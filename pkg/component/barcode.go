@@ -0,0 +1,161 @@
+package component
+
+import (
+	"image"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/aztec"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/datamatrix"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+	"github.com/signintech/gopdf"
+)
+
+// Symbology encodes data into a scannable barcode image at the given
+// pixel dimensions, abstracting over the underlying barcode library so
+// Barcode can stay agnostic of which symbology it's drawing.
+type Symbology interface {
+	Encode(data string, width, height int) (image.Image, error)
+}
+
+// ECLevel is a QR code's error-correction level: how much of the code can
+// be damaged or obscured while still scanning successfully, traded off
+// against code density.
+type ECLevel byte
+
+const (
+	ECLevelL ECLevel = iota // ~7% recoverable
+	ECLevelM                // ~15% recoverable
+	ECLevelQ                // ~25% recoverable
+	ECLevelH                // ~30% recoverable
+)
+
+func (l ECLevel) toQR() qr.ErrorCorrectionLevel {
+	switch l {
+	case ECLevelL:
+		return qr.L
+	case ECLevelQ:
+		return qr.Q
+	case ECLevelH:
+		return qr.H
+	default:
+		return qr.M
+	}
+}
+
+type qrSymbology struct {
+	level ECLevel
+}
+
+func (s qrSymbology) Encode(data string, width, height int) (image.Image, error) {
+	bc, err := qr.Encode(data, s.level.toQR(), qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+	return barcode.Scale(bc, width, height)
+}
+
+type code128Symbology struct{}
+
+func (code128Symbology) Encode(data string, width, height int) (image.Image, error) {
+	bc, err := code128.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return barcode.Scale(bc, width, height)
+}
+
+type ean13Symbology struct{}
+
+func (ean13Symbology) Encode(data string, width, height int) (image.Image, error) {
+	bc, err := ean.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return barcode.Scale(bc, width, height)
+}
+
+type aztecSymbology struct{}
+
+func (aztecSymbology) Encode(data string, width, height int) (image.Image, error) {
+	bc, err := aztec.Encode([]byte(data), 33, 0)
+	if err != nil {
+		return nil, err
+	}
+	return barcode.Scale(bc, width, height)
+}
+
+type dataMatrixSymbology struct{}
+
+func (dataMatrixSymbology) Encode(data string, width, height int) (image.Image, error) {
+	bc, err := datamatrix.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return barcode.Scale(bc, width, height)
+}
+
+// Symbologies usable with NewBarcode that don't need extra parameters.
+// QR codes carry their own error-correction level, so they're built via
+// NewQR instead of a package-level Symbology value.
+var (
+	Code128    Symbology = code128Symbology{}
+	EAN13      Symbology = ean13Symbology{}
+	Aztec      Symbology = aztecSymbology{}
+	DataMatrix Symbology = dataMatrixSymbology{}
+)
+
+// Barcode renders data through a pluggable Symbology, covering both 1D
+// codes (Code128, EAN-13) and 2D codes (QR, Aztec, DataMatrix) behind one
+// component.
+type Barcode struct {
+	Data      string
+	Symbology Symbology
+	Options   Options
+}
+
+// NewBarcode creates a barcode rendered with the given Symbology.
+func NewBarcode(symbology Symbology, data string, opts ...OptionFunc) *Barcode {
+	options := DefaultOptions()
+	options.Size = Size{Width: 55, Height: 55}
+	ApplyOptions(&options, opts...)
+	return &Barcode{
+		Data:      data,
+		Symbology: symbology,
+		Options:   options,
+	}
+}
+
+// NewQR creates a QR code barcode at the given error-correction level.
+func NewQR(data string, level ECLevel, opts ...OptionFunc) *Barcode {
+	return NewBarcode(qrSymbology{level: level}, data, opts...)
+}
+
+// Draw renders the barcode.
+func (b *Barcode) Draw(pdf *gopdf.GoPdf) float64 {
+	opts := b.Options
+
+	img, err := b.Symbology.Encode(b.Data, opts.ModuleSize, opts.ModuleSize)
+	if err != nil {
+		return 0
+	}
+
+	pdf.ImageFrom(img, opts.Position.X+opts.QuietZone, opts.Position.Y+opts.QuietZone, &gopdf.Rect{
+		W: opts.Size.Width - 2*opts.QuietZone,
+		H: opts.Size.Height - 2*opts.QuietZone,
+	})
+
+	return opts.Size.Height
+}
+
+// Measure reports the barcode's height without drawing it. It implements
+// Measurer.
+func (b *Barcode) Measure(pdf *gopdf.GoPdf) float64 {
+	return b.Options.Size.Height
+}
+
+// SetPosition moves the barcode's draw origin. It implements Positioner.
+func (b *Barcode) SetPosition(x, y float64) {
+	b.Options.Position = Position{X: x, Y: y}
+}
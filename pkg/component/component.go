@@ -4,6 +4,8 @@
 package component
 
 import (
+	"bill-generator/pkg/locale"
+
 	"github.com/signintech/gopdf"
 )
 
@@ -50,23 +52,61 @@ func DefaultStyle() Style {
 
 // Options configures component behavior and appearance.
 type Options struct {
-	Position  Position
-	Size      Size
-	Style     Style
-	Alignment Alignment
-	RTL       bool
-	Border    bool
-	WrapText  bool
+	Position   Position
+	Size       Size
+	Style      Style
+	Alignment  Alignment
+	RTL        bool
+	Border     bool
+	WrapText   bool
+	ModuleSize int     // Pixel resolution a Barcode is rendered at before being scaled into Size
+	QuietZone  float64 // Blank margin, in PDF units, left around a Barcode's code
+
+	// Locale, when set, is consulted by every component for text direction
+	// and font selection instead of RTL/Style.FontName, so a single
+	// document can mix locales (e.g. Arabic labels with English SKU cells)
+	// without each component having to agree on one RTL bool or font.
+	Locale *locale.Locale
+}
+
+// isRTL reports whether a component should lay its text out right-to-left:
+// Locale's IsRTL bit if a Locale is set, otherwise the plain RTL bool.
+func (o Options) isRTL() bool {
+	if o.Locale != nil {
+		return o.Locale.IsRTL
+	}
+	return o.RTL
+}
+
+// fontName reports the font a component should select: Locale's
+// FontFamily/FontFamilyBold when a Locale is set and names one, otherwise
+// Style.FontName with a "Bold" suffix applied for the bold variant, same
+// as every component did before Locale existed.
+func (o Options) fontName(bold bool) string {
+	if o.Locale != nil {
+		if bold && o.Locale.FontFamilyBold != "" {
+			return o.Locale.FontFamilyBold
+		}
+		if !bold && o.Locale.FontFamily != "" {
+			return o.Locale.FontFamily
+		}
+	}
+	if bold {
+		return o.Style.FontName + "Bold"
+	}
+	return o.Style.FontName
 }
 
 // DefaultOptions returns sensible default options.
 func DefaultOptions() Options {
 	return Options{
-		Style:     DefaultStyle(),
-		Alignment: AlignLeft,
-		RTL:       false,
-		Border:    false,
-		WrapText:  false,
+		Style:      DefaultStyle(),
+		Alignment:  AlignLeft,
+		RTL:        false,
+		Border:     false,
+		WrapText:   false,
+		ModuleSize: 256,
+		QuietZone:  0,
 	}
 }
 
@@ -77,6 +117,23 @@ type Component interface {
 	Draw(pdf *gopdf.GoPdf) float64
 }
 
+// Measurer is implemented by components that can report the height they'll
+// consume without drawing anything, so a layout engine (see Page) can
+// decide whether a Row fits on the current page before drawing any of it.
+// Components that don't implement it are drawn without a pre-flight
+// page-break check.
+type Measurer interface {
+	Measure(pdf *gopdf.GoPdf) float64
+}
+
+// Positioner is implemented by components whose Options.Position can be
+// moved after construction, so a layout engine (see Page) can place them
+// within a column once that column's x/y is known, rather than requiring
+// every drawable's absolute position to be computed up front by hand.
+type Positioner interface {
+	SetPosition(x, y float64)
+}
+
 // Container manages a collection of components and their layout.
 type Container interface {
 	// Add appends a component to the container.
@@ -159,6 +216,30 @@ func WithPadding(p float64) OptionFunc {
 	}
 }
 
+// WithModuleSize sets the pixel resolution a Barcode is rendered at
+// before gopdf scales it into Size.
+func WithModuleSize(size int) OptionFunc {
+	return func(o *Options) {
+		o.ModuleSize = size
+	}
+}
+
+// WithQuietZone sets the blank margin, in PDF units, left around a
+// Barcode's code within Size.
+func WithQuietZone(q float64) OptionFunc {
+	return func(o *Options) {
+		o.QuietZone = q
+	}
+}
+
+// WithLocale sets the locale every component consults for text direction
+// and font selection (see Options.Locale).
+func WithLocale(loc *locale.Locale) OptionFunc {
+	return func(o *Options) {
+		o.Locale = loc
+	}
+}
+
 // ApplyOptions applies a list of option functions to Options.
 func ApplyOptions(opts *Options, fns ...OptionFunc) {
 	for _, fn := range fns {
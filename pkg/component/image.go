@@ -0,0 +1,37 @@
+package component
+
+import "github.com/signintech/gopdf"
+
+// Image draws a picture file (PNG/JPEG) at its configured Size.
+type Image struct {
+	Path    string
+	Options Options
+}
+
+// NewImage creates an image component loaded from path.
+func NewImage(path string, opts ...OptionFunc) *Image {
+	options := DefaultOptions()
+	ApplyOptions(&options, opts...)
+	return &Image{Path: path, Options: options}
+}
+
+// Draw renders the image. It implements Component.
+func (i *Image) Draw(pdf *gopdf.GoPdf) float64 {
+	opts := i.Options
+	pdf.Image(i.Path, opts.Position.X, opts.Position.Y, &gopdf.Rect{
+		W: opts.Size.Width,
+		H: opts.Size.Height,
+	})
+	return opts.Size.Height
+}
+
+// Measure reports the image's height without drawing it. It implements
+// Measurer.
+func (i *Image) Measure(pdf *gopdf.GoPdf) float64 {
+	return i.Options.Size.Height
+}
+
+// SetPosition moves the image's draw origin. It implements Positioner.
+func (i *Image) SetPosition(x, y float64) {
+	i.Options.Position = Position{X: x, Y: y}
+}
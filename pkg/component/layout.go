@@ -0,0 +1,218 @@
+package component
+
+import (
+	"github.com/signintech/gopdf"
+)
+
+// GridColumns is the number of units a Row's Cols divide a Page's usable
+// width into, following the common 12-column grid convention.
+const GridColumns = 12
+
+// Col is a single column within a Row. It occupies Units out of
+// GridColumns of the Row's width and stacks its Drawables top to bottom.
+type Col struct {
+	Units     int
+	Drawables []Component
+}
+
+// NewCol creates a column spanning units out of GridColumns, hosting the
+// given drawables stacked top to bottom.
+func NewCol(units int, drawables ...Component) Col {
+	return Col{Units: units, Drawables: drawables}
+}
+
+// Row is a horizontal slice of a Page whose Cols' Units should sum to
+// GridColumns. A Row is already Page's atomic "keep together" unit - every
+// Col's Drawables are measured as one block and, if they don't fit in the
+// remaining page space, the whole Row moves to a fresh page rather than
+// splitting mid-row (Table.DrawPaginated is the one deliberate exception;
+// see drawRow).
+type Row struct {
+	Cols []Col
+	// PageBreakBefore forces this row to start on a fresh page, regardless
+	// of how much space remains on the current one - e.g. a section the
+	// caller wants to always begin at the top of a page.
+	PageBreakBefore bool
+	// MinSpaceRemaining is the minimum space, beyond this row's own
+	// measured height, that must remain below it on the current page; a
+	// nonzero value forces a page break before the row even when the row
+	// itself would technically fit, e.g. to keep a short row from being
+	// stranded alone at the bottom of a page with no room left for what
+	// follows it.
+	MinSpaceRemaining float64
+}
+
+// NewRow creates a row from the given columns.
+func NewRow(cols ...Col) Row {
+	return Row{Cols: cols}
+}
+
+// Page lays out a sequence of Rows on a PDF, breaking to a new page
+// whenever the next row would overflow the usable area and re-emitting a
+// registered header/footer on every page, including continuation pages.
+// pkg/htmlrender's TemplateData.Row is what builds a Page's Rows in
+// practice - see cmd/generator's RENDER_ENGINE=component option for the
+// real entry point that renders one.
+type Page struct {
+	Width        float64
+	Height       float64
+	MarginTop    float64
+	MarginRight  float64
+	MarginBottom float64
+	MarginLeft   float64
+	Debug        bool
+	rows         []Row
+	headerFn     func(pdf *gopdf.GoPdf) float64
+	footerFn     func(pdf *gopdf.GoPdf)
+}
+
+// NewPage creates a page with the given dimensions and margins.
+func NewPage(width, height, marginTop, marginRight, marginBottom, marginLeft float64) *Page {
+	return &Page{
+		Width:        width,
+		Height:       height,
+		MarginTop:    marginTop,
+		MarginRight:  marginRight,
+		MarginBottom: marginBottom,
+		MarginLeft:   marginLeft,
+	}
+}
+
+// AddRow appends a row to the page's layout.
+func (p *Page) AddRow(row Row) {
+	p.rows = append(p.rows, row)
+}
+
+// Rows returns the rows added to the page so far.
+func (p *Page) Rows() []Row {
+	return p.rows
+}
+
+// RegisterHeader sets the function drawn at the top of every page
+// (including continuation pages after a break). It must return the Y
+// coordinate content should resume at.
+func (p *Page) RegisterHeader(fn func(pdf *gopdf.GoPdf) float64) {
+	p.headerFn = fn
+}
+
+// RegisterFooter sets the function drawn at the bottom of every page
+// before a new one is started.
+func (p *Page) RegisterFooter(fn func(pdf *gopdf.GoPdf)) {
+	p.footerFn = fn
+}
+
+// usableWidth returns the width available for Cols between the left and
+// right margins.
+func (p *Page) usableWidth() float64 {
+	return p.Width - p.MarginLeft - p.MarginRight
+}
+
+// maxY returns the Y coordinate below which content must not be drawn.
+func (p *Page) maxY() float64 {
+	return p.Height - p.MarginBottom
+}
+
+// breakPage draws the footer on the current page, starts a new one, draws
+// the header, and returns the Y coordinate content should resume at.
+func (p *Page) breakPage(pdf *gopdf.GoPdf) float64 {
+	if p.footerFn != nil {
+		p.footerFn(pdf)
+	}
+	pdf.AddPage()
+	return p.drawHeader(pdf)
+}
+
+// drawHeader draws the registered header (if any) and returns the Y
+// coordinate content should resume at.
+func (p *Page) drawHeader(pdf *gopdf.GoPdf) float64 {
+	if p.headerFn != nil {
+		return p.headerFn(pdf)
+	}
+	return p.MarginTop
+}
+
+// Render draws every row in order onto pdf, starting a new page whenever a
+// row would overflow the usable area. Each Col's Drawables are measured
+// (via Measurer, when implemented) before being drawn, and in Debug mode a
+// rectangle is stroked around every row and column for layout debugging.
+func (p *Page) Render(pdf *gopdf.GoPdf) {
+	y := p.drawHeader(pdf)
+	pageStartY := y
+
+	for _, row := range p.rows {
+		rowHeight := p.measureRow(pdf, row)
+		needed := rowHeight + row.MinSpaceRemaining
+		// A forced break is a no-op when the row is already the first
+		// thing on a fresh page, so it doesn't open a spurious blank page.
+		if (row.PageBreakBefore && y != pageStartY) || y+needed > p.maxY() {
+			y = p.breakPage(pdf)
+			pageStartY = y
+			rowHeight = p.measureRow(pdf, row)
+		}
+
+		p.drawRow(pdf, row, y, rowHeight)
+		y += rowHeight
+	}
+
+	if p.footerFn != nil {
+		p.footerFn(pdf)
+	}
+}
+
+// measureRow returns the tallest height any of row's columns will consume.
+func (p *Page) measureRow(pdf *gopdf.GoPdf, row Row) float64 {
+	height := 0.0
+	for _, col := range row.Cols {
+		colHeight := 0.0
+		for _, d := range col.Drawables {
+			if m, ok := d.(Measurer); ok {
+				colHeight += m.Measure(pdf)
+			}
+		}
+		if colHeight > height {
+			height = colHeight
+		}
+	}
+	return height
+}
+
+// drawRow draws every column in row starting at y, using rowHeight (as
+// computed by measureRow) for the Debug-mode bounding rectangles. Tables
+// are drawn via DrawPaginated so they can break mid-row onto a
+// continuation page that repeats this Page's header and the table's own
+// column header.
+func (p *Page) drawRow(pdf *gopdf.GoPdf, row Row, y, rowHeight float64) {
+	x := p.MarginLeft
+	unitWidth := p.usableWidth() / GridColumns
+
+	for _, col := range row.Cols {
+		colWidth := unitWidth * float64(col.Units)
+		colY := y
+
+		for _, d := range col.Drawables {
+			if positioner, ok := d.(Positioner); ok {
+				positioner.SetPosition(x, colY)
+			}
+
+			if table, ok := d.(*Table); ok {
+				colY += table.DrawPaginated(pdf, p.maxY(), p.breakPage)
+				continue
+			}
+			colY += d.Draw(pdf)
+		}
+
+		if p.Debug {
+			pdf.SetStrokeColor(200, 0, 0)
+			pdf.SetLineWidth(0.3)
+			pdf.RectFromUpperLeftWithStyle(x, y, colWidth, rowHeight, "D")
+		}
+
+		x += colWidth
+	}
+
+	if p.Debug {
+		pdf.SetStrokeColor(0, 0, 200)
+		pdf.SetLineWidth(0.3)
+		pdf.RectFromUpperLeftWithStyle(p.MarginLeft, y, p.usableWidth(), rowHeight, "D")
+	}
+}
@@ -0,0 +1,207 @@
+package component
+
+import (
+	"strings"
+
+	"bill-generator/arabictext"
+
+	"github.com/signintech/gopdf"
+)
+
+// Party is one contact panel's content: an optional bold label followed by
+// ordered plain-text lines (e.g. name, address, tax ID), mirroring
+// pkg/pdf's contactLines. A blank line is skipped rather than drawn.
+type Party struct {
+	Label string
+	Lines []string
+}
+
+// lineCount reports how many lines p actually draws - its Label (if set)
+// plus every non-blank entry in Lines.
+func (p Party) lineCount() int {
+	n := 0
+	if p.Label != "" {
+		n++
+	}
+	for _, line := range p.Lines {
+		if line != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// PartyBlock renders two contact panels (e.g. an issuer and a customer)
+// side by side within its Size, flipping which panel is on the physical
+// left/right when Options is RTL - mirroring pkg/pdf's
+// drawIssuerCustomerBlocks, but as a reusable component.
+type PartyBlock struct {
+	Left    Party
+	Right   Party
+	Options Options
+}
+
+// NewPartyBlock creates a party block with the given left/right panels.
+func NewPartyBlock(left, right Party, opts ...OptionFunc) *PartyBlock {
+	options := DefaultOptions()
+	ApplyOptions(&options, opts...)
+	return &PartyBlock{
+		Left:    left,
+		Right:   right,
+		Options: options,
+	}
+}
+
+// SetPosition moves the party block's draw origin. It implements
+// Positioner.
+func (p *PartyBlock) SetPosition(x, y float64) {
+	p.Options.Position = Position{X: x, Y: y}
+}
+
+// Draw renders both panels side by side and returns the height consumed.
+// It implements Component.
+func (p *PartyBlock) Draw(pdf *gopdf.GoPdf) float64 {
+	opts := p.Options
+	isRTL := opts.isRTL()
+
+	panelWidth := opts.Size.Width / 2
+	leftX, rightX := opts.Position.X, opts.Position.X+panelWidth
+	if isRTL {
+		leftX, rightX = rightX, leftX
+	}
+
+	leftEnd := p.drawParty(pdf, p.Left, leftX, opts.Position.Y, isRTL)
+	rightEnd := p.drawParty(pdf, p.Right, rightX, opts.Position.Y, isRTL)
+
+	bottom := leftEnd
+	if rightEnd > bottom {
+		bottom = rightEnd
+	}
+	return bottom - opts.Position.Y
+}
+
+// drawParty draws a single panel's label and lines starting at (x, y) and
+// returns the Y position just below its last line.
+func (p *PartyBlock) drawParty(pdf *gopdf.GoPdf, party Party, x, y float64, isRTL bool) float64 {
+	opts := p.Options
+
+	if party.Label != "" {
+		if err := pdf.SetFont(opts.fontName(true), "", int(opts.Style.FontSize)); err != nil {
+			pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
+		}
+		pdf.SetTextColor(0, 0, 0)
+		label := party.Label
+		if isRTL {
+			label = arabictext.Process(label)
+		}
+		pdf.SetXY(x, y)
+		pdf.Cell(nil, label)
+		y += opts.Style.LineHeight
+	}
+
+	if err := pdf.SetFont(opts.fontName(false), "", int(opts.Style.FontSize)); err != nil {
+		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
+	}
+	pdf.SetTextColor(0, 0, 0)
+	for _, line := range party.Lines {
+		if line == "" {
+			continue
+		}
+		text := line
+		if isRTL {
+			text = arabictext.Process(line)
+		}
+		pdf.SetXY(x, y)
+		pdf.Cell(nil, text)
+		y += opts.Style.LineHeight
+	}
+	return y
+}
+
+// Measure reports the party block's height - the taller of its two
+// panels - without drawing anything. It implements Measurer.
+func (p *PartyBlock) Measure(pdf *gopdf.GoPdf) float64 {
+	lines := p.Left.lineCount()
+	if r := p.Right.lineCount(); r > lines {
+		lines = r
+	}
+	return float64(lines) * p.Options.Style.LineHeight
+}
+
+// PaymentField is a single labeled value PaymentBlock draws (e.g. "Due
+// Date" / "2026-08-01"); an empty Value is omitted entirely.
+type PaymentField struct {
+	Label string
+	Value string
+}
+
+// PaymentBlock renders a single row of labeled payment fields (due date,
+// IBAN, SWIFT, reference, ...), omitting whichever fields are empty and
+// drawing nothing at all when every field is - mirroring pkg/pdf's
+// drawPaymentInfo, but as a reusable component.
+type PaymentBlock struct {
+	Fields  []PaymentField
+	Options Options
+}
+
+// NewPaymentBlock creates a payment block from the given fields.
+func NewPaymentBlock(fields []PaymentField, opts ...OptionFunc) *PaymentBlock {
+	options := DefaultOptions()
+	ApplyOptions(&options, opts...)
+	return &PaymentBlock{
+		Fields:  fields,
+		Options: options,
+	}
+}
+
+// SetPosition moves the payment block's draw origin. It implements
+// Positioner.
+func (p *PaymentBlock) SetPosition(x, y float64) {
+	p.Options.Position = Position{X: x, Y: y}
+}
+
+// text joins every non-empty field as "Label Value", separated the same
+// way pkg/pdf's drawPaymentInfo joins its parts.
+func (p *PaymentBlock) text() string {
+	var parts []string
+	for _, f := range p.Fields {
+		if f.Value == "" {
+			continue
+		}
+		parts = append(parts, strings.TrimSpace(f.Label+" "+f.Value))
+	}
+	return strings.Join(parts, "    ")
+}
+
+// Draw renders the payment row and returns the height consumed - zero when
+// every field is empty. It implements Component.
+func (p *PaymentBlock) Draw(pdf *gopdf.GoPdf) float64 {
+	text := p.text()
+	if text == "" {
+		return 0
+	}
+
+	opts := p.Options
+	if err := pdf.SetFont(opts.fontName(opts.Style.Bold), "", int(opts.Style.FontSize)); err != nil {
+		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
+	}
+	pdf.SetTextColor(0, 0, 0)
+
+	if opts.isRTL() {
+		text = arabictext.Process(text)
+	}
+	pdf.SetXY(opts.Position.X+opts.Style.Padding, opts.Position.Y+opts.Style.Padding)
+	pdf.Cell(nil, text)
+
+	return opts.Style.LineHeight
+}
+
+// Measure reports the payment row's height, or zero when every field is
+// empty (so a layout engine can skip reserving space for it). It
+// implements Measurer.
+func (p *PaymentBlock) Measure(pdf *gopdf.GoPdf) float64 {
+	if p.text() == "" {
+		return 0
+	}
+	return p.Options.Style.LineHeight
+}
@@ -1,12 +1,14 @@
 package component
 
 import (
-	"os"
-
 	"github.com/signintech/gopdf"
 	"github.com/skip2/go-qrcode"
 )
 
+// qrImageSize is the pixel resolution the QR code is rendered at in
+// memory before gopdf scales it down to the component's Size.
+const qrImageSize = 256
+
 // QRCode renders a QR code image.
 type QRCode struct {
 	Data    string
@@ -28,13 +30,10 @@ func NewQRCode(data string, opts ...OptionFunc) *QRCode {
 func (q *QRCode) Draw(pdf *gopdf.GoPdf) float64 {
 	opts := q.Options
 
-	// Generate QR code to temp file
-	tmpFile := "/tmp/invoice_qr.png"
-	err := qrcode.WriteFile(q.Data, qrcode.High, 256, tmpFile)
+	img, err := qrcode.New(q.Data, qrcode.High)
 	if err != nil {
 		return 0
 	}
-	defer os.Remove(tmpFile)
 
 	// Calculate centered position if width is provided
 	x := opts.Position.X
@@ -43,7 +42,7 @@ func (q *QRCode) Draw(pdf *gopdf.GoPdf) float64 {
 		// But we need to know container width - use Size.Width as QR size
 	}
 
-	pdf.Image(tmpFile, x, opts.Position.Y, &gopdf.Rect{
+	pdf.ImageFrom(img.Image(qrImageSize), x, opts.Position.Y, &gopdf.Rect{
 		W: opts.Size.Width,
 		H: opts.Size.Height,
 	})
@@ -51,6 +50,17 @@ func (q *QRCode) Draw(pdf *gopdf.GoPdf) float64 {
 	return opts.Size.Height
 }
 
+// Measure reports the QR code's height without drawing it. It implements
+// Measurer.
+func (q *QRCode) Measure(pdf *gopdf.GoPdf) float64 {
+	return q.Options.Size.Height
+}
+
+// SetPosition moves the QR code's draw origin. It implements Positioner.
+func (q *QRCode) SetPosition(x, y float64) {
+	q.Options.Position = Position{X: x, Y: y}
+}
+
 // CenteredQRCode creates a QR code centered within a container.
 type CenteredQRCode struct {
 	Data           string
@@ -75,21 +85,30 @@ func NewCenteredQRCode(data string, qrSize, containerWidth float64, opts ...Opti
 func (q *CenteredQRCode) Draw(pdf *gopdf.GoPdf) float64 {
 	opts := q.Options
 
-	// Generate QR code
-	tmpFile := "/tmp/invoice_qr.png"
-	err := qrcode.WriteFile(q.Data, qrcode.High, 256, tmpFile)
+	img, err := qrcode.New(q.Data, qrcode.High)
 	if err != nil {
 		return 0
 	}
-	defer os.Remove(tmpFile)
 
 	// Calculate centered X position
 	x := opts.Position.X + (q.ContainerWidth-q.QRSize)/2
 
-	pdf.Image(tmpFile, x, opts.Position.Y, &gopdf.Rect{
+	pdf.ImageFrom(img.Image(qrImageSize), x, opts.Position.Y, &gopdf.Rect{
 		W: q.QRSize,
 		H: q.QRSize,
 	})
 
 	return q.QRSize
 }
+
+// Measure reports the centered QR code's height without drawing it. It
+// implements Measurer.
+func (q *CenteredQRCode) Measure(pdf *gopdf.GoPdf) float64 {
+	return q.QRSize
+}
+
+// SetPosition moves the centered QR code's draw origin. It implements
+// Positioner.
+func (q *CenteredQRCode) SetPosition(x, y float64) {
+	q.Options.Position = Position{X: x, Y: y}
+}
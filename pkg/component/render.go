@@ -0,0 +1,43 @@
+package component
+
+import (
+	"bytes"
+
+	"bill-generator/pkg/htmlpdf"
+	"bill-generator/pkg/models"
+)
+
+// Engine selects which backend RenderPDF uses to turn an invoice into PDF
+// bytes.
+type Engine int
+
+const (
+	// EngineNative renders via the caller-supplied native func, normally
+	// pdf.NewGenerator(fontDir).GenerateBytes - manual SetXY/Cell
+	// positioning, but no external dependency.
+	EngineNative Engine = iota
+	// EngineHTML renders via pkg/htmlpdf: an html/template document
+	// converted to PDF by a pluggable htmlpdf.Engine (wkhtmltopdf by
+	// default), trading that dependency for CSS-based RTL layout, proper
+	// page headers/footers, and complex tables.
+	EngineHTML
+)
+
+// RenderPDF renders inv to PDF bytes using the selected Engine. native
+// backs EngineNative - pass pdf.NewGenerator(fontDir).GenerateBytes; it's
+// taken as a func rather than calling pkg/pdf directly since pkg/pdf
+// already imports pkg/zatca, which imports pkg/component, and pkg/component
+// importing pkg/pdf back would be a cycle. htmlEngine backs EngineHTML and
+// may be nil to use htmlpdf's wkhtmltopdf default.
+func RenderPDF(inv models.Invoice, engine Engine, native func(models.Invoice) ([]byte, error), htmlEngine htmlpdf.Engine) ([]byte, error) {
+	switch engine {
+	case EngineHTML:
+		var buf bytes.Buffer
+		if err := htmlpdf.NewRenderer(htmlEngine).Render(inv, &buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return native(inv)
+	}
+}
@@ -0,0 +1,60 @@
+package component
+
+import "github.com/signintech/gopdf"
+
+// Spacer reserves vertical whitespace between other components without
+// drawing anything itself.
+type Spacer struct {
+	Height float64
+}
+
+// NewSpacer creates a spacer of the given height.
+func NewSpacer(height float64) *Spacer {
+	return &Spacer{Height: height}
+}
+
+// Draw consumes Height without drawing anything. It implements Component.
+func (s *Spacer) Draw(pdf *gopdf.GoPdf) float64 {
+	return s.Height
+}
+
+// Measure reports Height. It implements Measurer.
+func (s *Spacer) Measure(pdf *gopdf.GoPdf) float64 {
+	return s.Height
+}
+
+// HRule draws a horizontal rule across Options.Size.Width at its Position.
+type HRule struct {
+	Options   Options
+	Thickness float64
+}
+
+// NewHRule creates a horizontal rule spanning width, opts.Size.Width pt wide
+// and thickness pt thick (default 0.5 when thickness <= 0).
+func NewHRule(width, thickness float64, opts ...OptionFunc) *HRule {
+	options := DefaultOptions()
+	options.Size = Size{Width: width}
+	ApplyOptions(&options, opts...)
+	if thickness <= 0 {
+		thickness = 0.5
+	}
+	return &HRule{Options: options, Thickness: thickness}
+}
+
+// Draw renders the rule. It implements Component.
+func (r *HRule) Draw(pdf *gopdf.GoPdf) float64 {
+	opts := r.Options
+	pdf.SetLineWidth(r.Thickness)
+	pdf.Line(opts.Position.X, opts.Position.Y, opts.Position.X+opts.Size.Width, opts.Position.Y)
+	return r.Thickness
+}
+
+// Measure reports the rule's thickness. It implements Measurer.
+func (r *HRule) Measure(pdf *gopdf.GoPdf) float64 {
+	return r.Thickness
+}
+
+// SetPosition moves the rule's draw origin. It implements Positioner.
+func (r *HRule) SetPosition(x, y float64) {
+	r.Options.Position = Position{X: x, Y: y}
+}
@@ -0,0 +1,139 @@
+package component
+
+import "github.com/signintech/gopdf"
+
+// VStack draws its children top to bottom starting at Options.Position,
+// each positioned (via Positioner, when implemented) directly below the
+// previous one. It implements Component and Container.
+type VStack struct {
+	Options  Options
+	children []Component
+}
+
+// NewVStack creates an empty vertical stack at the given position.
+func NewVStack(opts ...OptionFunc) *VStack {
+	options := DefaultOptions()
+	ApplyOptions(&options, opts...)
+	return &VStack{Options: options}
+}
+
+// Add appends a child component. It implements Container.
+func (s *VStack) Add(c Component) {
+	s.children = append(s.children, c)
+}
+
+// Draw renders every child in order, stacking them vertically. It
+// implements Component.
+func (s *VStack) Draw(pdf *gopdf.GoPdf) float64 {
+	x, y := s.Options.Position.X, s.Options.Position.Y
+	total := 0.0
+	for _, c := range s.children {
+		if p, ok := c.(Positioner); ok {
+			p.SetPosition(x, y+total)
+		}
+		total += c.Draw(pdf)
+	}
+	return total
+}
+
+// Render is an alias for Draw. It implements Container.
+func (s *VStack) Render(pdf *gopdf.GoPdf) float64 {
+	return s.Draw(pdf)
+}
+
+// Measure reports the stack's total height without drawing it. It
+// implements Measurer; children that don't implement Measurer themselves
+// are assumed to take no height.
+func (s *VStack) Measure(pdf *gopdf.GoPdf) float64 {
+	total := 0.0
+	for _, c := range s.children {
+		if m, ok := c.(Measurer); ok {
+			total += m.Measure(pdf)
+		}
+	}
+	return total
+}
+
+// SetPosition moves the stack's draw origin. It implements Positioner.
+func (s *VStack) SetPosition(x, y float64) {
+	s.Options.Position = Position{X: x, Y: y}
+}
+
+// hCell pairs an HStack child with the width it occupies, since a
+// Component alone doesn't expose one uniformly (see HStack.Add).
+type hCell struct {
+	c     Component
+	width float64
+}
+
+// HStack draws its children left to right (or right to left when RTL)
+// starting at Options.Position, each occupying the width given to Add. It
+// implements Component; unlike VStack, it doesn't implement Container,
+// since laying children side by side needs a width per child rather than
+// the single-argument Add(Component) the Container interface allows for.
+type HStack struct {
+	Options Options
+	cells   []hCell
+}
+
+// NewHStack creates an empty horizontal stack at the given position.
+func NewHStack(opts ...OptionFunc) *HStack {
+	options := DefaultOptions()
+	ApplyOptions(&options, opts...)
+	return &HStack{Options: options}
+}
+
+// Add appends a child component occupying width points of horizontal
+// space.
+func (s *HStack) Add(c Component, width float64) {
+	s.cells = append(s.cells, hCell{c: c, width: width})
+}
+
+// Draw renders every child left to right (or right to left when RTL),
+// each offset by the running total of its predecessors' widths. It
+// implements Component.
+func (s *HStack) Draw(pdf *gopdf.GoPdf) float64 {
+	y := s.Options.Position.Y
+	x := s.Options.Position.X
+	rtl := s.Options.isRTL()
+	if rtl {
+		x += s.Options.Size.Width
+	}
+
+	maxHeight := 0.0
+	for _, cell := range s.cells {
+		if rtl {
+			x -= cell.width
+		}
+		if p, ok := cell.c.(Positioner); ok {
+			p.SetPosition(x, y)
+		}
+		if h := cell.c.Draw(pdf); h > maxHeight {
+			maxHeight = h
+		}
+		if !rtl {
+			x += cell.width
+		}
+	}
+	return maxHeight
+}
+
+// Measure reports the tallest child's height without drawing anything. It
+// implements Measurer; children that don't implement Measurer themselves
+// are assumed to take no height.
+func (s *HStack) Measure(pdf *gopdf.GoPdf) float64 {
+	maxHeight := 0.0
+	for _, cell := range s.cells {
+		if m, ok := cell.c.(Measurer); ok {
+			if h := m.Measure(pdf); h > maxHeight {
+				maxHeight = h
+			}
+		}
+	}
+	return maxHeight
+}
+
+// SetPosition moves the stack's draw origin. It implements Positioner.
+func (s *HStack) SetPosition(x, y float64) {
+	s.Options.Position = Position{X: x, Y: y}
+}
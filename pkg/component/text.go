@@ -27,17 +27,14 @@ func (t *TextBlock) Draw(pdf *gopdf.GoPdf) float64 {
 	opts := t.Options
 
 	// Set font
-	fontName := opts.Style.FontName
-	if opts.Style.Bold {
-		fontName = fontName + "Bold"
-	}
+	fontName := opts.fontName(opts.Style.Bold)
 	if err := pdf.SetFont(fontName, "", int(opts.Style.FontSize)); err != nil {
 		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
 	}
 
 	// Process text for RTL if needed
 	text := t.Text
-	if opts.RTL {
+	if opts.isRTL() {
 		text = arabictext.Process(text)
 	}
 
@@ -67,6 +64,18 @@ func (t *TextBlock) Draw(pdf *gopdf.GoPdf) float64 {
 	return opts.Style.LineHeight
 }
 
+// Measure reports the text block's height without drawing it. It
+// implements Measurer.
+func (t *TextBlock) Measure(pdf *gopdf.GoPdf) float64 {
+	return t.Options.Style.LineHeight
+}
+
+// SetPosition moves the text block's draw origin. It implements
+// Positioner.
+func (t *TextBlock) SetPosition(x, y float64) {
+	t.Options.Position = Position{X: x, Y: y}
+}
+
 // LabelValuePair renders a label on one side and value on the other.
 type LabelValuePair struct {
 	Label   string
@@ -90,10 +99,7 @@ func (lv *LabelValuePair) Draw(pdf *gopdf.GoPdf) float64 {
 	opts := lv.Options
 
 	// Set font
-	fontName := opts.Style.FontName
-	if opts.Style.Bold {
-		fontName = fontName + "Bold"
-	}
+	fontName := opts.fontName(opts.Style.Bold)
 	if err := pdf.SetFont(fontName, "", int(opts.Style.FontSize)); err != nil {
 		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
 	}
@@ -103,14 +109,15 @@ func (lv *LabelValuePair) Draw(pdf *gopdf.GoPdf) float64 {
 	// Process text
 	label := lv.Label
 	value := lv.Value
-	if opts.RTL {
+	isRTL := opts.isRTL()
+	if isRTL {
 		label = arabictext.Process(label)
 	}
 
 	labelW, _ := pdf.MeasureTextWidth(label)
 	valueW, _ := pdf.MeasureTextWidth(value)
 
-	if opts.RTL {
+	if isRTL {
 		// Label on right, value on left
 		pdf.SetXY(opts.Position.X+opts.Size.Width-labelW-opts.Style.Padding, opts.Position.Y)
 		pdf.Cell(nil, label)
@@ -150,16 +157,13 @@ func NewHeader(text string, opts ...OptionFunc) *Header {
 func (h *Header) Draw(pdf *gopdf.GoPdf) float64 {
 	opts := h.Options
 
-	fontName := opts.Style.FontName
-	if opts.Style.Bold {
-		fontName = fontName + "Bold"
-	}
+	fontName := opts.fontName(opts.Style.Bold)
 	if err := pdf.SetFont(fontName, "", int(opts.Style.FontSize)); err != nil {
 		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
 	}
 
 	text := h.Text
-	if opts.RTL {
+	if opts.isRTL() {
 		text = arabictext.Process(text)
 	}
 
@@ -172,6 +176,17 @@ func (h *Header) Draw(pdf *gopdf.GoPdf) float64 {
 	return opts.Style.LineHeight + 6
 }
 
+// Measure reports the header's height without drawing it. It implements
+// Measurer.
+func (h *Header) Measure(pdf *gopdf.GoPdf) float64 {
+	return h.Options.Style.LineHeight + 6
+}
+
+// SetPosition moves the header's draw origin. It implements Positioner.
+func (h *Header) SetPosition(x, y float64) {
+	h.Options.Position = Position{X: x, Y: y}
+}
+
 // WrappedText handles long text that needs to wrap across lines.
 type WrappedText struct {
 	Text    string
@@ -193,45 +208,14 @@ func NewWrappedText(text string, opts ...OptionFunc) *WrappedText {
 func (w *WrappedText) Draw(pdf *gopdf.GoPdf) float64 {
 	opts := w.Options
 
-	fontName := opts.Style.FontName
-	if opts.Style.Bold {
-		fontName = fontName + "Bold"
-	}
+	fontName := opts.fontName(opts.Style.Bold)
 	if err := pdf.SetFont(fontName, "", int(opts.Style.FontSize)); err != nil {
 		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
 	}
 
 	pdf.SetTextColor(0, 0, 0)
 
-	text := w.Text
-	if opts.RTL {
-		text = arabictext.Process(text)
-	}
-
-	// Simple word wrapping
-	maxWidth := opts.Size.Width - (2 * opts.Style.Padding)
-	words := splitWords(text)
-	lines := []string{}
-	currentLine := ""
-
-	for _, word := range words {
-		testLine := currentLine
-		if testLine != "" {
-			testLine += " "
-		}
-		testLine += word
-
-		lineW, _ := pdf.MeasureTextWidth(testLine)
-		if lineW > maxWidth && currentLine != "" {
-			lines = append(lines, currentLine)
-			currentLine = word
-		} else {
-			currentLine = testLine
-		}
-	}
-	if currentLine != "" {
-		lines = append(lines, currentLine)
-	}
+	lines := w.wrap(pdf)
 
 	// Draw lines
 	y := opts.Position.Y + opts.Style.Padding
@@ -254,6 +238,62 @@ func (w *WrappedText) Draw(pdf *gopdf.GoPdf) float64 {
 	return float64(len(lines)) * opts.Style.LineHeight
 }
 
+// Measure reports the wrapped text's height without drawing it. It
+// implements Measurer.
+func (w *WrappedText) Measure(pdf *gopdf.GoPdf) float64 {
+	opts := w.Options
+
+	fontName := opts.fontName(opts.Style.Bold)
+	if err := pdf.SetFont(fontName, "", int(opts.Style.FontSize)); err != nil {
+		pdf.SetFont(opts.Style.FontName, "", int(opts.Style.FontSize))
+	}
+
+	return float64(len(w.wrap(pdf))) * opts.Style.LineHeight
+}
+
+// SetPosition moves the wrapped text's draw origin. It implements
+// Positioner.
+func (w *WrappedText) SetPosition(x, y float64) {
+	w.Options.Position = Position{X: x, Y: y}
+}
+
+// wrap splits Text into lines that fit within the component's width. It
+// tokenizes and measures the unshaped, logical-order text via
+// arabictext.WrapShaped (see textutil.WrapText, which uses the same
+// approach) and only then shapes each resulting line for RTL - shaping the
+// whole text up front and measuring logical-order words against it, as
+// this used to do, mismeasures: a word's shaped width isn't the sum of its
+// letters' standalone widths. Shared by Draw and Measure so the measured
+// and drawn heights never drift apart.
+func (w *WrappedText) wrap(pdf *gopdf.GoPdf) []string {
+	opts := w.Options
+	isRTL := opts.isRTL()
+	maxWidth := opts.Size.Width - (2 * opts.Style.Padding)
+
+	processedText := w.Text
+	if isRTL {
+		processedText = arabictext.Process(w.Text)
+	}
+	if textWidth, _ := pdf.MeasureTextWidth(processedText); textWidth <= maxWidth {
+		return []string{processedText}
+	}
+
+	rawLines := arabictext.WrapShaped(w.Text, func(s string) float64 {
+		lineW, _ := pdf.MeasureTextWidth(s)
+		return lineW
+	}, maxWidth)
+
+	lines := make([]string, len(rawLines))
+	for i, raw := range rawLines {
+		if isRTL {
+			lines[i] = arabictext.Process(raw)
+		} else {
+			lines[i] = raw
+		}
+	}
+	return lines
+}
+
 func splitWords(text string) []string {
 	words := []string{}
 	current := ""
@@ -0,0 +1,120 @@
+package htmlpdf
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"bill-generator/arabictext"
+	"bill-generator/pkg/models"
+)
+
+//go:embed templates/*.html
+var defaultTemplates embed.FS
+
+// templateFuncs are available to every registered template. "arabic"
+// reshapes and reorders a string for correct RTL rendering, via
+// arabictext.Process, so template authors don't need to know about
+// shaping/BiDi internals. "discountSummary" renders a product's named
+// allowances/charges as a single cell, for the Discount column templates
+// show when Labels.DiscountColumn is set.
+var templateFuncs = template.FuncMap{
+	"arabic":          arabictext.Process,
+	"discountSummary": discountSummary,
+	"totalDiscount":   totalDiscount,
+}
+
+// discountSummary renders a product's named allowances/charges as a single
+// "reason -amount; reason +amount" string, or "" when there are none.
+func discountSummary(acs []models.AllowanceCharge) string {
+	if len(acs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(acs))
+	for i, ac := range acs {
+		sign := "-"
+		if ac.ChargeIndicator {
+			sign = "+"
+		}
+		if ac.Reason != "" {
+			parts[i] = fmt.Sprintf("%s %s%.2f", ac.Reason, sign, ac.Amount)
+		} else {
+			parts[i] = fmt.Sprintf("%s%.2f", sign, ac.Amount)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// totalDiscount returns the invoice-wide discount total the Total Discount
+// row shows: every product line's discount plus any invoice-level
+// allowance, mirroring pkg/htmlrender's Totals().
+func totalDiscount(totals models.Totals) float64 {
+	return totals.LineDiscount + totals.InvoiceDiscount
+}
+
+// Renderer renders a models.Invoice through a per-language html/template
+// and converts the result to PDF via Engine.
+type Renderer struct {
+	Engine    Engine
+	templates map[string]*template.Template
+}
+
+// NewRenderer creates a Renderer backed by engine, pre-registered with the
+// default ar/en thermal-receipt templates and an "a4" variant. A nil engine
+// defaults to CommandEngine{} (wkhtmltopdf).
+func NewRenderer(engine Engine) *Renderer {
+	if engine == nil {
+		engine = CommandEngine{}
+	}
+	r := &Renderer{Engine: engine, templates: map[string]*template.Template{}}
+	for _, lang := range []string{"ar", "en", "a4"} {
+		tmpl := template.Must(template.New(lang+".html").Funcs(templateFuncs).ParseFS(defaultTemplates, "templates/"+lang+".html"))
+		r.templates[lang] = tmpl
+	}
+	return r
+}
+
+// RegisterTemplate associates tmpl with lang, overriding any default or
+// previously registered template for that language. lang is matched
+// against Invoice.Language by Render; register "default" to change the
+// fallback used when Language has no matching template.
+func (r *Renderer) RegisterTemplate(lang string, tmpl *template.Template) {
+	if r.templates == nil {
+		r.templates = map[string]*template.Template{}
+	}
+	r.templates[lang] = tmpl
+}
+
+// Render executes the template registered for inv.Language (falling back
+// to "en", then "default" if registered) and converts the resulting HTML
+// to PDF via Engine, writing it to w.
+func (r *Renderer) Render(inv models.Invoice, w io.Writer) error {
+	tmpl, name := r.templateFor(inv.Language)
+	if tmpl == nil {
+		return fmt.Errorf("htmlpdf: no template registered for language %q", inv.Language)
+	}
+
+	var html bytes.Buffer
+	if err := tmpl.Execute(&html, inv); err != nil {
+		return fmt.Errorf("htmlpdf: failed to render template %q: %w", name, err)
+	}
+	return r.Engine.Convert(html.Bytes(), w)
+}
+
+// templateFor resolves lang to a registered template, falling back to
+// "en" and then "default".
+func (r *Renderer) templateFor(lang string) (*template.Template, string) {
+	if tmpl, ok := r.templates[lang]; ok {
+		return tmpl, lang
+	}
+	if tmpl, ok := r.templates["en"]; ok {
+		return tmpl, "en"
+	}
+	if tmpl, ok := r.templates["default"]; ok {
+		return tmpl, "default"
+	}
+	return nil, ""
+}
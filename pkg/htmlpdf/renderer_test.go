@@ -0,0 +1,112 @@
+package htmlpdf
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"strings"
+	"testing"
+
+	"bill-generator/pkg/models"
+)
+
+// recordingEngine captures the HTML it was asked to convert instead of
+// shelling out, so tests can assert on template output without requiring
+// wkhtmltopdf to be installed.
+type recordingEngine struct {
+	html []byte
+}
+
+func (e *recordingEngine) Convert(html []byte, w io.Writer) error {
+	e.html = html
+	_, err := w.Write([]byte("%PDF-fake"))
+	return err
+}
+
+func testInvoice() models.Invoice {
+	return models.Invoice{
+		Title:             "Invoice",
+		InvoiceNumber:     "INV-001",
+		StoreName:         "Test Store",
+		VATRegistrationNo: "123456789",
+		Language:          "en",
+		Products: []models.Product{
+			{Name: "Widget", Quantity: 2, UnitPrice: 50, VATAmount: 13.5, TotalWithVAT: 113.5},
+		},
+		TotalTaxableAmt: 100,
+		TotalWithVAT:    113.5,
+	}
+}
+
+func TestRender_UsesDefaultEnglishTemplate(t *testing.T) {
+	engine := &recordingEngine{}
+	r := NewRenderer(engine)
+
+	var out bytes.Buffer
+	if err := r.Render(testInvoice(), &out); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if out.Len() == 0 {
+		t.Fatal("expected Render to write PDF bytes produced by the engine")
+	}
+	if !strings.Contains(string(engine.html), "INV-001") {
+		t.Errorf("expected rendered HTML to include the invoice number, got:\n%s", engine.html)
+	}
+}
+
+func TestRender_UnknownLanguageFallsBackToEnglish(t *testing.T) {
+	engine := &recordingEngine{}
+	r := NewRenderer(engine)
+
+	inv := testInvoice()
+	inv.Language = "fr"
+	if err := r.Render(inv, &bytes.Buffer{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(string(engine.html), "INV-001") {
+		t.Errorf("expected fallback to the English template, got:\n%s", engine.html)
+	}
+}
+
+func TestRegisterTemplate_OverridesLanguage(t *testing.T) {
+	engine := &recordingEngine{}
+	r := NewRenderer(engine)
+	r.RegisterTemplate("en", template.Must(template.New("custom").Parse("custom:{{.InvoiceNumber}}")))
+
+	if err := r.Render(testInvoice(), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if string(engine.html) != "custom:INV-001" {
+		t.Errorf("expected the registered override to be used, got:\n%s", engine.html)
+	}
+}
+
+func TestRender_DiscountColumnShownOnlyWhenLabeled(t *testing.T) {
+	engine := &recordingEngine{}
+	r := NewRenderer(engine)
+
+	inv := testInvoice()
+	if err := r.Render(inv, &bytes.Buffer{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(string(engine.html), "Discount") {
+		t.Errorf("expected no Discount column without a DiscountColumn label, got:\n%s", engine.html)
+	}
+
+	inv.Labels.DiscountColumn = "Discount"
+	inv.Labels.TotalDiscount = "Total Discount:"
+	inv.Products[0].AllowanceCharges = []models.AllowanceCharge{{Reason: "Promo", Amount: 5}}
+	inv.Totals.LineDiscount = 5
+
+	engine2 := &recordingEngine{}
+	r2 := NewRenderer(engine2)
+	if err := r2.Render(inv, &bytes.Buffer{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(string(engine2.html), "Promo") {
+		t.Errorf("expected the discount summary in the rendered HTML, got:\n%s", engine2.html)
+	}
+	if !strings.Contains(string(engine2.html), "Total Discount:") {
+		t.Errorf("expected the Total Discount row in the rendered HTML, got:\n%s", engine2.html)
+	}
+}
@@ -0,0 +1,148 @@
+// Package htmlrender renders invoices authored as Go html/template files
+// into a PDF, using pkg/component's grid layout engine instead of an
+// external HTML-to-PDF binary (compare pkg/invoice.HTMLRenderer, which
+// shells out to one).
+//
+// A template's actions aren't rendering markup to be parsed back out -
+// they call builder methods on the TemplateData they execute against,
+// which record component.Component nodes into a component.Page as a side
+// effect. The template's own text output is discarded. This keeps
+// invoice presentation (which components appear, in what order, how
+// wide) out of the hardcoded layout in pkg/pdf.Generator, while still
+// producing a PDF through the pure-Go pipeline.
+package htmlrender
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"bill-generator/arabictext"
+	"bill-generator/pkg/component"
+	"bill-generator/pkg/models"
+
+	"github.com/signintech/gopdf"
+)
+
+//go:embed templates/default.html
+var defaultTemplates embed.FS
+
+// DefaultTemplateName is the embedded template NewDefaultRenderer uses.
+const DefaultTemplateName = "templates/default.html"
+
+// Renderer renders invoices by executing a named html/template file
+// against a TemplateData, then laying out the resulting component.Page.
+type Renderer struct {
+	Templates fs.FS
+	Name      string // template file name within Templates, e.g. "invoice.html"
+	FontDir   string
+
+	PageWidth    float64
+	PageHeight   float64
+	MarginTop    float64
+	MarginRight  float64
+	MarginBottom float64
+	MarginLeft   float64
+}
+
+// NewRenderer creates a Renderer with sensible defaults (an 80mm thermal
+// receipt page with 10pt margins on every side).
+func NewRenderer(templates fs.FS, name, fontDir string) *Renderer {
+	return &Renderer{
+		Templates:    templates,
+		Name:         name,
+		FontDir:      fontDir,
+		PageWidth:    226.77,
+		PageHeight:   708.66,
+		MarginTop:    10,
+		MarginRight:  10,
+		MarginBottom: 10,
+		MarginLeft:   10,
+	}
+}
+
+// NewDefaultRenderer creates a Renderer using the package's embedded
+// default template, which draws a header, the products table, and a
+// totals/QR code row.
+func NewDefaultRenderer(fontDir string) *Renderer {
+	return NewRenderer(defaultTemplates, DefaultTemplateName, fontDir)
+}
+
+// RenderPDF executes the renderer's template against inv and cfg and
+// returns the resulting PDF bytes.
+func (r *Renderer) RenderPDF(inv models.Invoice, cfg models.Config) ([]byte, error) {
+	tmpl, err := template.New(path.Base(r.Name)).Funcs(funcMap(cfg)).ParseFS(r.Templates, r.Name)
+	if err != nil {
+		return nil, fmt.Errorf("htmlrender: parse template: %w", err)
+	}
+
+	page := component.NewPage(r.PageWidth, r.PageHeight, r.MarginTop, r.MarginRight, r.MarginBottom, r.MarginLeft)
+	data := &TemplateData{Invoice: inv, Config: cfg, page: page}
+
+	if err := tmpl.Execute(io.Discard, data); err != nil {
+		return nil, fmt.Errorf("htmlrender: execute template: %w", err)
+	}
+
+	pdfDoc := gopdf.GoPdf{}
+	pdfDoc.Start(gopdf.Config{PageSize: gopdf.Rect{W: r.PageWidth, H: r.PageHeight}})
+	if err := r.loadFonts(&pdfDoc); err != nil {
+		return nil, err
+	}
+	pdfDoc.AddPage()
+
+	page.Render(&pdfDoc)
+
+	return pdfDoc.GetBytesPdfReturnErr()
+}
+
+func (r *Renderer) loadFonts(pdfDoc *gopdf.GoPdf) error {
+	regularPath := r.FontDir + "/Amiri-Regular.ttf"
+	if err := pdfDoc.AddTTFFont("Amiri", regularPath); err != nil {
+		return fmt.Errorf("htmlrender: failed to load regular font: %w", err)
+	}
+
+	boldPath := r.FontDir + "/Amiri-Bold.ttf"
+	if err := pdfDoc.AddTTFFont("AmiriBold", boldPath); err != nil {
+		// Fallback to regular
+		_ = pdfDoc.AddTTFFont("AmiriBold", regularPath)
+	}
+
+	return nil
+}
+
+// funcMap returns the template helpers available to inline template
+// expressions: arabic for RTL shaping, currency for amounts formatted
+// with cfg's currency symbol, and date for reformatting an ISO-8601
+// (2006-01-02) date string per cfg.DateFormat.
+func funcMap(cfg models.Config) template.FuncMap {
+	return template.FuncMap{
+		"arabic":   arabictext.Process,
+		"currency": func(amount float64) string { return formatCurrency(amount, cfg.CurrencySymbol) },
+		"date":     func(value string) string { return formatDate(value, cfg.DateFormat) },
+	}
+}
+
+func formatCurrency(amount float64, symbol string) string {
+	formatted := component.FormatNumber(amount, 2)
+	if symbol == "" {
+		return formatted
+	}
+	return formatted + " " + symbol
+}
+
+// formatDate reformats value from ISO-8601 (2006-01-02) into layout,
+// leaving it unchanged if it doesn't parse as a date or layout is empty.
+func formatDate(value, layout string) string {
+	if layout == "" {
+		return value
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return value
+	}
+	return t.Format(layout)
+}
@@ -0,0 +1,80 @@
+package htmlrender
+
+import (
+	"testing"
+
+	"bill-generator/pkg/models"
+)
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount float64
+		symbol string
+		want   string
+	}{
+		{"with symbol", 12.5, "SAR", "12.50 SAR"},
+		{"no symbol", 12.5, "", "12.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCurrency(tt.amount, tt.symbol); got != tt.want {
+				t.Errorf("formatCurrency(%v, %q) = %q, want %q", tt.amount, tt.symbol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		layout string
+		want   string
+	}{
+		{"reformats a valid date", "2026-07-30", "02/01/2006", "30/07/2026"},
+		{"empty layout leaves value unchanged", "2026-07-30", "", "2026-07-30"},
+		{"unparseable value falls back unchanged", "not-a-date", "02/01/2006", "not-a-date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDate(tt.value, tt.layout); got != tt.want {
+				t.Errorf("formatDate(%q, %q) = %q, want %q", tt.value, tt.layout, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowanceChargeSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		acs  []models.AllowanceCharge
+		want string
+	}{
+		{"none", nil, ""},
+		{"single discount with reason", []models.AllowanceCharge{{Amount: 10, Reason: "loyalty discount"}}, "loyalty discount -10.00"},
+		{"single charge without reason", []models.AllowanceCharge{{Amount: 2, ChargeIndicator: true}}, "+2.00"},
+		{
+			"discount and charge combined",
+			[]models.AllowanceCharge{
+				{Amount: 10, Reason: "loyalty discount"},
+				{Amount: 2, ChargeIndicator: true, Reason: "bag fee"},
+			},
+			"loyalty discount -10.00; bag fee +2.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allowanceChargeSummary(tt.acs); got != tt.want {
+				t.Errorf("allowanceChargeSummary(%v) = %q, want %q", tt.acs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDefaultRenderer_ParsesEmbeddedTemplate(t *testing.T) {
+	r := NewDefaultRenderer("/fonts")
+	if _, err := r.Templates.Open(r.Name); err != nil {
+		t.Fatalf("expected embedded default template to be readable: %v", err)
+	}
+}
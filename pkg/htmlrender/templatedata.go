@@ -0,0 +1,121 @@
+package htmlrender
+
+import (
+	"fmt"
+	"strings"
+
+	"bill-generator/pkg/component"
+	"bill-generator/pkg/models"
+)
+
+// TemplateData is the context a template executes against. Its methods
+// double as template actions and as the builder for the resulting
+// component.Page: a method like Row both returns a value safe to discard
+// as template output and appends to the page being built, so a template
+// can write {{.Row (.Col 12 (.Header .Invoice.Title))}} and have it take
+// effect without printing anything.
+type TemplateData struct {
+	Invoice models.Invoice
+	Config  models.Config
+
+	page *component.Page
+}
+
+// Col wraps drawables into a column spanning units out of
+// component.GridColumns, for composing into Row.
+func (d *TemplateData) Col(units int, drawables ...component.Component) component.Col {
+	return component.NewCol(units, drawables...)
+}
+
+// Row appends a row of columns to the page being built. It always
+// returns an empty string so it's safe to use directly as a template
+// action.
+func (d *TemplateData) Row(cols ...component.Col) string {
+	d.page.AddRow(component.NewRow(cols...))
+	return ""
+}
+
+// Header builds a centered header component from text.
+func (d *TemplateData) Header(text string) *component.Header {
+	return component.NewHeader(text, component.WithRTL(d.Invoice.IsRTL))
+}
+
+// Table builds a table component listing the invoice's line items. A
+// discount/allowance column is included only when the invoice supplies a
+// DiscountColumn label, so invoices without any allowances/charges keep
+// their existing column layout.
+func (d *TemplateData) Table() *component.Table {
+	labels := d.Invoice.Labels
+	showAllowances := labels.DiscountColumn != ""
+
+	columns := []component.TableColumn{
+		{Header: []string{labels.ProductColumn}, Width: 140, Align: component.AlignLeft},
+		{Header: []string{labels.QuantityColumn}, Width: 40, Align: component.AlignCenter},
+		{Header: []string{labels.UnitPriceColumn}, Width: 60, Align: component.AlignRight},
+	}
+	if showAllowances {
+		columns = append(columns, component.TableColumn{Header: []string{labels.DiscountColumn}, Width: 70, Align: component.AlignRight})
+	}
+	columns = append(columns,
+		component.TableColumn{Header: []string{labels.VATAmountColumn}, Width: 60, Align: component.AlignRight},
+		component.TableColumn{Header: []string{labels.TotalColumn}, Width: 60, Align: component.AlignRight},
+	)
+
+	t := component.NewTable(columns, component.WithRTL(d.Invoice.IsRTL))
+	for _, p := range d.Invoice.Products {
+		cells := []string{p.Name, component.FormatNumber(p.Quantity, 2), d.currency(p.UnitPrice)}
+		wrap := []bool{true, false, false}
+		if showAllowances {
+			cells = append(cells, allowanceChargeSummary(p.AllowanceCharges))
+			wrap = append(wrap, true)
+		}
+		cells = append(cells, d.currency(p.VATAmount), d.currency(p.TotalWithVAT))
+		wrap = append(wrap, false, false)
+		t.AddRow(cells, wrap)
+	}
+	return t
+}
+
+// allowanceChargeSummary renders a product's named allowances/charges as a
+// single "reason -amount; reason +amount" cell, or "" when there are none.
+func allowanceChargeSummary(acs []models.AllowanceCharge) string {
+	if len(acs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(acs))
+	for i, ac := range acs {
+		sign := "-"
+		if ac.ChargeIndicator {
+			sign = "+"
+		}
+		if ac.Reason != "" {
+			parts[i] = fmt.Sprintf("%s %s%.2f", ac.Reason, sign, ac.Amount)
+		} else {
+			parts[i] = fmt.Sprintf("%s%.2f", sign, ac.Amount)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Totals builds the invoice's summary totals table component.
+func (d *TemplateData) Totals() *component.TotalsTable {
+	labels := d.Invoice.Labels
+	t := component.NewTotalsTable(100, 60, component.WithRTL(d.Invoice.IsRTL))
+	if discount := d.Invoice.Totals.LineDiscount + d.Invoice.Totals.InvoiceDiscount; discount > 0 && labels.TotalDiscount != "" {
+		t.AddRow(labels.TotalDiscount, d.currency(discount), false, false)
+	}
+	t.AddRow(labels.TotalTaxable, d.currency(d.Invoice.TotalTaxableAmt), false, false)
+	t.AddRow(labels.TotalWithVat, d.currency(d.Invoice.TotalWithVAT), true, true)
+	return t
+}
+
+// QRCode builds a QR code component from the invoice's QR code payload.
+func (d *TemplateData) QRCode() *component.QRCode {
+	return component.NewQRCode(d.Invoice.QRCodeData)
+}
+
+// currency formats an amount with the configured currency symbol,
+// matching the `currency` template func available to inline expressions.
+func (d *TemplateData) currency(amount float64) string {
+	return formatCurrency(amount, d.Config.CurrencySymbol)
+}
@@ -0,0 +1,63 @@
+package htmlrender
+
+import (
+	"html/template"
+	"io"
+	"testing"
+
+	"bill-generator/pkg/component"
+	"bill-generator/pkg/models"
+)
+
+func TestTemplateData_RowAppendsToPage(t *testing.T) {
+	page := component.NewPage(226.77, 708.66, 10, 10, 10, 10)
+	data := &TemplateData{
+		Invoice: models.Invoice{Title: "Invoice"},
+		page:    page,
+	}
+
+	out := data.Row(data.Col(12, data.Header("hello")))
+	if out != "" {
+		t.Errorf("expected Row to return an empty string, got %q", out)
+	}
+
+	if got := len(page.Rows()); got != 1 {
+		t.Errorf("expected Row to append exactly one row to the page, got %d", got)
+	}
+}
+
+func TestDefaultTemplate_ExecutesAgainstInvoice(t *testing.T) {
+	inv := models.Invoice{
+		Title: "Test Invoice",
+		Labels: models.Labels{
+			ProductColumn:   "Product",
+			QuantityColumn:  "Qty",
+			UnitPriceColumn: "Price",
+			VATAmountColumn: "VAT",
+			TotalColumn:     "Total",
+			TotalTaxable:    "Subtotal",
+			TotalWithVat:    "Total",
+		},
+		Products: []models.Product{
+			{Name: "Widget", Quantity: 2, UnitPrice: 10, VATAmount: 3, TotalWithVAT: 23},
+		},
+		QRCodeData: "invoice-123",
+	}
+	cfg := models.Config{CurrencySymbol: "SAR"}
+
+	tmpl, err := template.New("default.html").Funcs(funcMap(cfg)).ParseFS(defaultTemplates, DefaultTemplateName)
+	if err != nil {
+		t.Fatalf("failed to parse embedded default template: %v", err)
+	}
+
+	page := component.NewPage(226.77, 708.66, 10, 10, 10, 10)
+	data := &TemplateData{Invoice: inv, Config: cfg, page: page}
+
+	if err := tmpl.Execute(io.Discard, data); err != nil {
+		t.Fatalf("failed to execute embedded default template: %v", err)
+	}
+
+	if got := len(page.Rows()); got != 3 {
+		t.Errorf("expected the default template to build 3 rows, got %d", got)
+	}
+}
@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"bill-generator/pkg/models"
+)
+
+// CSVSource loads one product line per CSV row (header row required; a
+// "vatCategory" column is the only one that isn't a number) plus invoice-level
+// metadata from a sidecar JSON file with InvoiceData's "config"/"invoice"/
+// "labels" shape, minus "products". The sidecar defaults to Filename with
+// ".meta.json" appended (e.g. "products.csv" -> "products.csv.meta.json") and
+// is optional - a missing sidecar just means an invoice with zero-value
+// header fields.
+type CSVSource struct {
+	Filename string
+	MetaFile string // defaults to Filename + ".meta.json" when empty
+}
+
+// Load implements Source.
+func (s CSVSource) Load(ctx context.Context) (models.Invoice, error) {
+	metaFile := s.MetaFile
+	if metaFile == "" {
+		metaFile = s.Filename + ".meta.json"
+	}
+
+	var data models.InvoiceData
+	if metaBytes, err := os.ReadFile(metaFile); err == nil {
+		if err := json.Unmarshal(metaBytes, &data); err != nil {
+			return models.Invoice{}, fmt.Errorf("failed to parse CSV metadata %s: %w", metaFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return models.Invoice{}, fmt.Errorf("failed to read CSV metadata %s: %w", metaFile, err)
+	}
+
+	products, err := readCSVProducts(s.Filename)
+	if err != nil {
+		return models.Invoice{}, err
+	}
+	data.Products = products
+
+	return BuildInvoice(data), nil
+}
+
+// readCSVProducts reads filename's header row and one models.ProductInput per
+// subsequent row. Column order doesn't matter - rows are mapped by header
+// name - and unrecognized columns are ignored.
+func readCSVProducts(filename string) ([]models.ProductInput, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header in %s: %w", filename, err)
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.TrimSpace(name)] = i
+	}
+
+	var products []models.ProductInput
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row in %s: %w", filename, err)
+		}
+
+		products = append(products, models.ProductInput{
+			Name:            csvField(row, col, "name"),
+			Quantity:        csvFloatField(row, col, "quantity"),
+			UnitPrice:       csvFloatField(row, col, "unitPrice"),
+			DiscountPercent: csvFloatField(row, col, "discountPercent"),
+			DiscountAmount:  csvFloatField(row, col, "discountAmount"),
+			ChargePercent:   csvFloatField(row, col, "chargePercent"),
+			ChargeAmount:    csvFloatField(row, col, "chargeAmount"),
+			VATCategory:     csvField(row, col, "vatCategory"),
+		})
+	}
+	return products, nil
+}
+
+// csvField returns the trimmed value of column name in row, or "" if the
+// header didn't have that column or the row is short.
+func csvField(row []string, col map[string]int, name string) string {
+	i, ok := col[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// csvFloatField parses column name as a float, or 0 on a missing column or
+// unparseable value.
+func csvFloatField(row []string, col map[string]int, name string) float64 {
+	v, _ := strconv.ParseFloat(csvField(row, col, name), 64)
+	return v
+}
@@ -0,0 +1,72 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCSVSource_LoadsProductsAndMetadata(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "products.csv")
+	metaPath := csvPath + ".meta.json"
+
+	csvData := "name,quantity,unitPrice,vatCategory\n" +
+		"Product 1,2,50,S\n" +
+		"Product 2,1,100,S\n"
+	if err := os.WriteFile(csvPath, []byte(csvData), 0644); err != nil {
+		t.Fatalf("failed to write CSV fixture: %v", err)
+	}
+
+	metaData := `{
+		"config": {"vatPercentage": 15},
+		"invoice": {"title": "Test Invoice", "invoiceNumber": "INV001"}
+	}`
+	if err := os.WriteFile(metaPath, []byte(metaData), 0644); err != nil {
+		t.Fatalf("failed to write metadata fixture: %v", err)
+	}
+
+	inv, err := CSVSource{Filename: csvPath}.Load(context.Background())
+	if err != nil {
+		t.Fatalf("CSVSource.Load failed: %v", err)
+	}
+
+	if inv.InvoiceNumber != "INV001" {
+		t.Errorf("InvoiceNumber = %q, want %q", inv.InvoiceNumber, "INV001")
+	}
+	if len(inv.Products) != 2 {
+		t.Fatalf("len(Products) = %d, want 2", len(inv.Products))
+	}
+	if inv.Products[0].Name != "Product 1" || inv.Products[0].Quantity != 2 {
+		t.Errorf("Products[0] = %+v, want Name=Product 1 Quantity=2", inv.Products[0])
+	}
+	if inv.Products[1].UnitPrice != 100 {
+		t.Errorf("Products[1].UnitPrice = %v, want 100", inv.Products[1].UnitPrice)
+	}
+}
+
+func TestCSVSource_MissingMetaFileIsNotAnError(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "products.csv")
+	if err := os.WriteFile(csvPath, []byte("name,quantity,unitPrice\nWidget,3,10\n"), 0644); err != nil {
+		t.Fatalf("failed to write CSV fixture: %v", err)
+	}
+
+	inv, err := CSVSource{Filename: csvPath}.Load(context.Background())
+	if err != nil {
+		t.Fatalf("CSVSource.Load failed: %v", err)
+	}
+	if len(inv.Products) != 1 || inv.Products[0].Name != "Widget" {
+		t.Errorf("Products = %+v, want a single Widget line", inv.Products)
+	}
+}
+
+func TestSourceForFile_PicksSourceByExtension(t *testing.T) {
+	if _, ok := SourceForFile("data.csv").(CSVSource); !ok {
+		t.Error("SourceForFile(\"data.csv\") did not return a CSVSource")
+	}
+	if _, ok := SourceForFile("data.json").(JSONFileSource); !ok {
+		t.Error("SourceForFile(\"data.json\") did not return a JSONFileSource")
+	}
+}
@@ -0,0 +1,36 @@
+package loader
+
+import (
+	"context"
+	"errors"
+
+	"bill-generator/pkg/models"
+)
+
+// GRPCSource loads invoice data from a BillService.RenderInvoice request (see
+// proto/invoice.proto) instead of a file, for cmd/bill-server.
+//
+// NOTE: this is a structural stub, not a working implementation. A real
+// GRPCSource needs generated client code from proto/invoice.proto, which
+// needs google.golang.org/grpc and google.golang.org/protobuf - neither is
+// available in this module's dependency set or its local module cache, and
+// this environment has no network access to go get them or run protoc. Load
+// fails clearly instead of silently returning a zero-value invoice; wiring
+// the generated invoicepb types through FromProto is the rest of this work.
+type GRPCSource struct {
+	// Request would be the generated *invoicepb.InvoiceRequest once that
+	// package exists.
+	Request any
+}
+
+// Load implements Source. It always fails - see the type's doc comment.
+func (s GRPCSource) Load(ctx context.Context) (models.Invoice, error) {
+	return models.Invoice{}, errors.New("loader: GRPCSource is not implemented (requires google.golang.org/grpc and generated proto/invoice.proto bindings, unavailable in this build)")
+}
+
+// FromProto would convert a generated *invoicepb.Invoice into InvoiceData for
+// BuildInvoice, field for field per proto/invoice.proto's comments. Left
+// unimplemented alongside GRPCSource for the same reason.
+func FromProto(req any) (models.InvoiceData, error) {
+	return models.InvoiceData{}, errors.New("loader: FromProto is not implemented (requires generated proto/invoice.proto bindings, unavailable in this build)")
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
+	"bill-generator/pkg/locale"
 	"bill-generator/pkg/models"
+	"bill-generator/pkg/zatca"
 )
 
 // LoadFromJSON reads a JSON file and returns a fully calculated Invoice.
@@ -29,6 +32,16 @@ func ParseJSON(data []byte) (models.Invoice, error) {
 	return BuildInvoice(invoiceData), nil
 }
 
+// firstNonEmpty returns the first non-empty string among vals.
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // BuildInvoice creates a complete Invoice from InvoiceData, calculating all derived values.
 func BuildInvoice(data models.InvoiceData) models.Invoice {
 	vatRate := data.Config.VATPercentage / 100.0
@@ -41,63 +54,195 @@ func BuildInvoice(data models.InvoiceData) models.Invoice {
 		isRTL = false
 	}
 
+	// Config.Locale, when it resolves, derives Language/IsRTL from the
+	// registered locale and fills in any Labels the caller left empty,
+	// so a partial "labels" object in the JSON still produces a complete
+	// label set.
+	labels := data.Labels
+	if data.Config.Locale != "" {
+		if loc, ok := locale.Lookup(data.Config.Locale); ok {
+			language, _, _ = strings.Cut(loc.Code, "-")
+			isRTL = loc.IsRTL
+			labels = locale.MergeLabels(loc.Labels, data.Labels)
+		}
+	}
+
 	// Calculate product values with discount support
 	products := make([]models.Product, 0, len(data.Products))
-	var totalGross, totalDiscount, totalTaxable, totalVAT float64
+	var totalGross, totalDiscount, totalCharge, totalTaxable, totalVAT float64
 
 	for _, p := range data.Products {
-		// Calculate gross amount (before discount)
+		// Calculate gross amount (before discount/charge)
 		grossAmount := p.Quantity * p.UnitPrice
 
 		// Calculate discount: percentage discount + fixed discount
 		discountAmt := (grossAmount * p.DiscountPercent / 100.0) + p.DiscountAmount
 
-		// Net amount after discount is the taxable amount
-		netAmount := grossAmount - discountAmt
-		if netAmount < 0 {
+		// Calculate charge: percentage charge + fixed charge, added back on
+		// top of the discount (e.g. a delivery fee or service charge)
+		chargeAmt := (grossAmount * p.ChargePercent / 100.0) + p.ChargeAmount
+
+		// Resolve each named AllowanceCharge (Percent against the line's own
+		// gross amount, additive with Amount) and fold it into the same
+		// discount/charge totals the anonymous fields above feed.
+		allowanceCharges := make([]models.AllowanceCharge, len(p.AllowanceCharges))
+		for i, ac := range p.AllowanceCharges {
+			amt := ac.Amount + (grossAmount * ac.Percent / 100.0)
+			allowanceCharges[i] = models.AllowanceCharge{
+				ChargeIndicator: ac.ChargeIndicator,
+				Amount:          amt,
+				Percent:         ac.Percent,
+				Reason:          ac.Reason,
+			}
+			if ac.ChargeIndicator {
+				chargeAmt += amt
+			} else {
+				discountAmt += amt
+			}
+		}
+
+		// Net amount after discount/charge is the taxable amount. Credit/debit
+		// note lines intentionally carry a negative quantity (and so a
+		// negative net amount) to reverse the original invoice; only clamp
+		// the over-discounted case on ordinary positive-quantity lines.
+		netAmount := grossAmount - discountAmt + chargeAmt
+		if netAmount < 0 && p.Quantity >= 0 {
 			netAmount = 0 // Prevent negative amounts
 		}
 
-		// VAT is calculated on net amount (after discount)
-		vatAmount := netAmount * vatRate
+		// VAT is calculated on net amount (after discount/charge), at this
+		// line's own VATRate when set, falling back to Config.VATPercentage
+		// so mixed-rate invoices (e.g. 0%/5%/15% items) compute correctly.
+		lineRate := vatRate
+		if p.VATRate != nil {
+			lineRate = *p.VATRate / 100.0
+		}
+		vatAmount := netAmount * lineRate
 		totalWithVAT := netAmount + vatAmount
 
 		products = append(products, models.Product{
-			Name:            p.Name,
-			Quantity:        p.Quantity,
-			UnitPrice:       p.UnitPrice,
-			DiscountPercent: p.DiscountPercent,
-			DiscountAmount:  discountAmt,
-			GrossAmount:     grossAmount,
-			NetAmount:       netAmount,
-			TaxableAmt:      netAmount,
-			VATAmount:       vatAmount,
-			TotalWithVAT:    totalWithVAT,
+			Name:             p.Name,
+			Quantity:         p.Quantity,
+			UnitPrice:        p.UnitPrice,
+			DiscountPercent:  p.DiscountPercent,
+			DiscountAmount:   discountAmt,
+			ChargeAmount:     chargeAmt,
+			AllowanceCharges: allowanceCharges,
+			GrossAmount:      grossAmount,
+			NetAmount:        netAmount,
+			TaxableAmt:       netAmount,
+			VATRate:          lineRate * 100.0,
+			VATAmount:        vatAmount,
+			TotalWithVAT:     totalWithVAT,
+			VATCategory:      p.VATCategory,
+			PageBreakBefore:  p.PageBreakBefore,
 		})
 
 		totalGross += grossAmount
 		totalDiscount += discountAmt
+		totalCharge += chargeAmt
 		totalTaxable += netAmount
 		totalVAT += vatAmount
 	}
 
-	return models.Invoice{
-		Title:             data.Invoice.Title,
-		InvoiceNumber:     data.Invoice.InvoiceNumber,
-		StoreName:         data.Invoice.StoreName,
-		StoreAddress:      data.Invoice.StoreAddress,
-		Date:              data.Invoice.Date,
-		VATRegistrationNo: data.Invoice.VATRegistrationNo,
-		Products:          products,
-		TotalGross:        totalGross,
-		TotalDiscount:     totalDiscount,
-		TotalTaxableAmt:   totalTaxable,
-		TotalVAT:          totalVAT,
-		TotalWithVAT:      totalTaxable + totalVAT,
-		QRCodeData:        data.Invoice.QRCodeData,
-		VATPercentage:     data.Config.VATPercentage,
-		Labels:            data.Labels,
-		Language:          language,
-		IsRTL:             isRTL,
+	// Invoice-level allowances/charges apply on top of every line's own, at
+	// the taxable-amount stage, the same ChargeIndicator/Amount/Percent
+	// convention as a product line's AllowanceCharges.
+	var invoiceDiscount, invoiceCharge float64
+	for _, ac := range data.Invoice.AllowanceCharges {
+		amt := ac.Amount + (totalTaxable * ac.Percent / 100.0)
+		if ac.ChargeIndicator {
+			invoiceCharge += amt
+		} else {
+			invoiceDiscount += amt
+		}
+	}
+	// Only clamp an over-discounted result on an ordinarily positive total;
+	// a credit/debit note's total is intentionally negative (see the
+	// per-line clamp above) and must pass through unchanged.
+	finalTaxable := totalTaxable - invoiceDiscount + invoiceCharge
+	if finalTaxable < 0 && totalTaxable >= 0 {
+		finalTaxable = 0
+	}
+	// Invoice-level adjustments aren't tied to any one line's VATRate, so
+	// they're taxed at Config.VATPercentage; each line's own VAT (already
+	// summed into totalVAT) keeps whatever rate it was computed at above.
+	finalVAT := totalVAT - invoiceDiscount*vatRate + invoiceCharge*vatRate
+
+	// Payment, under the "payment" JSON key, is the preferred way to set
+	// due date/IBAN/SWIFT/reference; the older flat fields remain as
+	// fallbacks for any of its fields left empty.
+	dueDate := firstNonEmpty(data.Invoice.Payment.DueDate, data.Invoice.DueDate)
+	iban := firstNonEmpty(data.Invoice.Payment.IBAN, data.Invoice.IBAN)
+	swift := firstNonEmpty(data.Invoice.Payment.SWIFT, data.Invoice.SWIFT)
+
+	inv := models.Invoice{
+		Title:               data.Invoice.Title,
+		InvoiceNumber:       data.Invoice.InvoiceNumber,
+		StoreName:           data.Invoice.StoreName,
+		StoreAddress:        data.Invoice.StoreAddress,
+		Date:                data.Invoice.Date,
+		VATRegistrationNo:   data.Invoice.VATRegistrationNo,
+		Customer:            data.Invoice.Customer,
+		Type:                data.Invoice.Type,
+		PrecedingReferences: data.Invoice.PrecedingReferences,
+		Products:            products,
+		TotalGross:          totalGross,
+		TotalDiscount:       totalDiscount + invoiceDiscount,
+		TotalTaxableAmt:     finalTaxable,
+		TotalVAT:            finalVAT,
+		TotalWithVAT:        finalTaxable + finalVAT,
+		QRCodeData:          data.Invoice.QRCodeData,
+		VATPercentage:       data.Config.VATPercentage,
+		Labels:              labels,
+		Language:            language,
+		IsRTL:               isRTL,
+		LogoPath:            data.Invoice.LogoPath,
+		LogoWidth:           data.Invoice.LogoWidth,
+		LogoHeight:          data.Invoice.LogoHeight,
+		StampPath:           data.Invoice.StampPath,
+		StampX:              data.Invoice.StampX,
+		StampY:              data.Invoice.StampY,
+		Compliance:          data.Invoice.Compliance,
+		InvoiceCounter:      data.Invoice.InvoiceCounter,
+		PreviousInvoiceHash: data.Invoice.PreviousInvoiceHash,
+		PageSize:            data.Config.PageSize,
+		Issuer:              data.Invoice.Issuer,
+		DueDate:             dueDate,
+		IBAN:                iban,
+		SWIFT:               swift,
+		PaymentReference:    data.Invoice.Payment.Reference,
+		Conformance:         data.Config.Conformance,
+		Attachments:         data.Invoice.Attachments,
+		Totals: models.Totals{
+			Gross:           totalGross,
+			LineDiscount:    totalDiscount,
+			LineCharge:      totalCharge,
+			InvoiceDiscount: invoiceDiscount,
+			InvoiceCharge:   invoiceCharge,
+			Taxable:         finalTaxable,
+			VAT:             finalVAT,
+			Total:           finalTaxable + finalVAT,
+		},
+	}
+
+	// ZATCA compliance derives the simplified (Phase-1) QR payload from the
+	// invoice fields themselves, so JSON-driven callers get it for free
+	// instead of having to assemble it by hand via invoice.Builder.WithQRCode.
+	// Phase-2 signing (tags 6-9, requiring an ECDSA cert) is still a
+	// separate step - see zatca.Sign - since BuildInvoice has no cert to
+	// work with.
+	if inv.Compliance == "zatca" && inv.QRCodeData == "" {
+		if qr, err := zatca.BuildQR(zatca.Invoice{
+			SellerName:   inv.StoreName,
+			VATNumber:    inv.VATRegistrationNo,
+			Timestamp:    inv.Date,
+			TotalWithVAT: inv.TotalWithVAT,
+			VATTotal:     inv.TotalVAT,
+		}); err == nil {
+			inv.QRCodeData = qr
+		}
 	}
+
+	return inv
 }
@@ -2,6 +2,8 @@ package loader
 
 import (
 	"testing"
+
+	"bill-generator/pkg/models"
 )
 
 func TestParseJSON(t *testing.T) {
@@ -136,6 +138,169 @@ func TestParseJSON_DefaultLanguage(t *testing.T) {
 	}
 }
 
+func TestParseJSON_LineAndInvoiceAllowanceCharges(t *testing.T) {
+	jsonData := []byte(`{
+		"config": {"vatPercentage": 15},
+		"invoice": {
+			"title": "Test", "invoiceNumber": "1", "storeName": "Store", "storeAddress": "Addr", "date": "2024/01/01", "vatRegistrationNo": "123", "qrCodeData": "qr",
+			"allowanceCharges": [{"amount": 5, "reason": "shipping", "chargeIndicator": true}]
+		},
+		"products": [
+			{"name": "Product", "quantity": 1, "unitPrice": 100, "allowanceCharges": [
+				{"percent": 10, "reason": "loyalty discount"},
+				{"amount": 2, "reason": "bag fee", "chargeIndicator": true}
+			]}
+		],
+		"labels": {"invoiceNumber": "", "date": "", "vatRegistration": "", "totalTaxable": "", "totalWithVat": "", "productColumn": "", "quantityColumn": "", "unitPriceColumn": "", "vatAmountColumn": "", "totalColumn": "", "footer": ""}
+	}`)
+
+	invoice, err := ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+
+	p := invoice.Products[0]
+	if len(p.AllowanceCharges) != 2 {
+		t.Fatalf("expected 2 resolved allowance/charges, got %d", len(p.AllowanceCharges))
+	}
+	// Gross: 100, line discount: 10% = 10, line charge: 2, net: 92
+	if p.DiscountAmount != 10 {
+		t.Errorf("expected line discount 10, got %.2f", p.DiscountAmount)
+	}
+	if p.ChargeAmount != 2 {
+		t.Errorf("expected line charge 2, got %.2f", p.ChargeAmount)
+	}
+	if p.NetAmount != 92 {
+		t.Errorf("expected net 92, got %.2f", p.NetAmount)
+	}
+	if p.AllowanceCharges[0].Amount != 10 {
+		t.Errorf("expected resolved loyalty discount amount 10, got %.2f", p.AllowanceCharges[0].Amount)
+	}
+
+	// Invoice-level shipping charge of 5 applies on top of the line's net
+	// amount: taxable = 92 + 5 = 97, VAT = 14.55, total = 111.55.
+	if invoice.TotalTaxableAmt != 97 {
+		t.Errorf("expected invoice taxable 97, got %.2f", invoice.TotalTaxableAmt)
+	}
+	if invoice.TotalWithVAT != 111.55 {
+		t.Errorf("expected invoice total 111.55, got %.2f", invoice.TotalWithVAT)
+	}
+	if invoice.Totals.InvoiceCharge != 5 {
+		t.Errorf("expected Totals.InvoiceCharge 5, got %.2f", invoice.Totals.InvoiceCharge)
+	}
+}
+
+func TestParseJSON_PerProductVATRate(t *testing.T) {
+	jsonData := []byte(`{
+		"config": {"vatPercentage": 15},
+		"invoice": {
+			"title": "Test", "invoiceNumber": "1", "storeName": "Store", "storeAddress": "Addr", "date": "2024/01/01", "vatRegistrationNo": "123", "qrCodeData": "qr"
+		},
+		"products": [
+			{"name": "Standard", "quantity": 1, "unitPrice": 100},
+			{"name": "ZeroRated", "quantity": 1, "unitPrice": 100, "vatRate": 0},
+			{"name": "Reduced", "quantity": 1, "unitPrice": 100, "vatRate": 5}
+		],
+		"labels": {"invoiceNumber": "", "date": "", "vatRegistration": "", "totalTaxable": "", "totalWithVat": "", "productColumn": "", "quantityColumn": "", "unitPriceColumn": "", "vatAmountColumn": "", "totalColumn": "", "footer": ""}
+	}`)
+
+	invoice, err := ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+
+	if got := invoice.Products[0].VATAmount; got != 15 {
+		t.Errorf("expected standard-rate line VAT 15, got %.2f", got)
+	}
+	if got := invoice.Products[1].VATAmount; got != 0 {
+		t.Errorf("expected zero-rated line VAT 0, got %.2f", got)
+	}
+	if got := invoice.Products[2].VATAmount; got != 5 {
+		t.Errorf("expected reduced-rate line VAT 5, got %.2f", got)
+	}
+	// 15 + 0 + 5 = 20
+	if invoice.TotalVAT != 20 {
+		t.Errorf("expected total VAT 20, got %.2f", invoice.TotalVAT)
+	}
+	if invoice.TotalWithVAT != 320 {
+		t.Errorf("expected total with VAT 320, got %.2f", invoice.TotalWithVAT)
+	}
+}
+
+func TestParseJSON_PaymentTermsFallsBackToFlatFields(t *testing.T) {
+	jsonData := []byte(`{
+		"config": {"vatPercentage": 15},
+		"invoice": {
+			"title": "Test", "invoiceNumber": "1", "storeName": "Store", "storeAddress": "Addr", "date": "2024/01/01", "vatRegistrationNo": "123", "qrCodeData": "qr",
+			"iban": "SA0000000000000000000000",
+			"payment": {"dueDate": "2024/02/01", "swift": "RIBLSARI", "reference": "INV-1"}
+		},
+		"products": [{"name": "Product", "quantity": 1, "unitPrice": 100}],
+		"labels": {"invoiceNumber": "", "date": "", "vatRegistration": "", "totalTaxable": "", "totalWithVat": "", "productColumn": "", "quantityColumn": "", "unitPriceColumn": "", "vatAmountColumn": "", "totalColumn": "", "footer": ""}
+	}`)
+
+	invoice, err := ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+
+	if invoice.DueDate != "2024/02/01" {
+		t.Errorf("expected DueDate from payment.dueDate, got %q", invoice.DueDate)
+	}
+	if invoice.IBAN != "SA0000000000000000000000" {
+		t.Errorf("expected IBAN to fall back to flat field, got %q", invoice.IBAN)
+	}
+	if invoice.SWIFT != "RIBLSARI" {
+		t.Errorf("expected SWIFT from payment.swift, got %q", invoice.SWIFT)
+	}
+	if invoice.PaymentReference != "INV-1" {
+		t.Errorf("expected PaymentReference, got %q", invoice.PaymentReference)
+	}
+}
+
+func TestParseJSON_LocaleFillsInMissingLabels(t *testing.T) {
+	jsonData := []byte(`{
+		"config": {"vatPercentage": 23, "locale": "pl-PL"},
+		"invoice": {"title": "Test", "invoiceNumber": "1", "storeName": "Store", "storeAddress": "Addr", "date": "2024/01/01", "vatRegistrationNo": "123"},
+		"products": [],
+		"labels": {"footer": "Custom footer"}
+	}`)
+
+	invoice, err := ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+
+	if invoice.Language != "pl" {
+		t.Errorf("expected language 'pl', got '%s'", invoice.Language)
+	}
+	if invoice.IsRTL {
+		t.Error("expected IsRTL to be false for Polish")
+	}
+	if invoice.Labels.Footer != "Custom footer" {
+		t.Errorf("expected the explicit footer label to survive, got '%s'", invoice.Labels.Footer)
+	}
+	if invoice.Labels.InvoiceNumber != "Nr faktury:" {
+		t.Errorf("expected the locale default to fill in the unset invoiceNumber label, got '%s'", invoice.Labels.InvoiceNumber)
+	}
+}
+
+func TestParseJSON_UnknownLocaleFallsBackToEnglishFlag(t *testing.T) {
+	jsonData := []byte(`{
+		"config": {"vatPercentage": 15, "locale": "xx-XX", "english": true},
+		"invoice": {"title": "Test", "invoiceNumber": "1", "storeName": "Store", "storeAddress": "Addr", "date": "2024/01/01", "vatRegistrationNo": "123"},
+		"products": []
+	}`)
+
+	invoice, err := ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+	if invoice.Language != "en" {
+		t.Errorf("expected fallback to the English flag, got language '%s'", invoice.Language)
+	}
+}
+
 func TestParseJSON_InvalidJSON(t *testing.T) {
 	jsonData := []byte(`{invalid json}`)
 
@@ -246,3 +411,43 @@ func TestParseJSON_CombinedDiscount(t *testing.T) {
 	}
 }
 
+func TestParseJSON_CreditNoteNegativeQuantity(t *testing.T) {
+	jsonData := []byte(`{
+		"config": {"vatPercentage": 15},
+		"invoice": {
+			"title": "Test", "invoiceNumber": "CN-1", "storeName": "Store", "storeAddress": "Addr",
+			"date": "2024/01/01", "vatRegistrationNo": "123", "qrCodeData": "qr",
+			"type": "credit",
+			"precedingReferences": [{"invoiceNumber": "INV-1", "issueDate": "2023/12/01", "reason": "returned goods"}]
+		},
+		"products": [
+			{"name": "Product", "quantity": -1, "unitPrice": 100}
+		],
+		"labels": {"invoiceNumber": "", "date": "", "vatRegistration": "", "totalTaxable": "", "totalWithVat": "", "productColumn": "", "quantityColumn": "", "unitPriceColumn": "", "vatAmountColumn": "", "totalColumn": "", "footer": ""}
+	}`)
+
+	invoice, err := ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+
+	if invoice.Type != models.InvoiceTypeCredit {
+		t.Errorf("Expected invoice type %q, got %q", models.InvoiceTypeCredit, invoice.Type)
+	}
+	if len(invoice.PrecedingReferences) != 1 || invoice.PrecedingReferences[0].InvoiceNumber != "INV-1" {
+		t.Fatalf("Expected a preceding reference to INV-1, got %+v", invoice.PrecedingReferences)
+	}
+
+	// Gross: -100, Net: -100, VAT: -15, Total: -115 (negative quantities are
+	// not clamped, so the credit note reverses the original invoice).
+	p := invoice.Products[0]
+	if p.NetAmount != -100 {
+		t.Errorf("Expected net -100, got %.2f", p.NetAmount)
+	}
+	if p.VATAmount != -15 {
+		t.Errorf("Expected VAT -15, got %.2f", p.VATAmount)
+	}
+	if invoice.TotalWithVAT != -115 {
+		t.Errorf("Expected total with VAT -115, got %.2f", invoice.TotalWithVAT)
+	}
+}
@@ -0,0 +1,38 @@
+package loader
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"bill-generator/pkg/models"
+)
+
+// Source loads invoice data from some external representation and returns a
+// fully calculated models.Invoice, so callers (cmd/generator, cmd/bill-server)
+// don't need to know whether the data came from a JSON file, a CSV file, or a
+// gRPC request.
+type Source interface {
+	Load(ctx context.Context) (models.Invoice, error)
+}
+
+// JSONFileSource loads invoice data from a JSON file in InvoiceData's shape,
+// via LoadFromJSON.
+type JSONFileSource struct {
+	Filename string
+}
+
+// Load implements Source.
+func (s JSONFileSource) Load(ctx context.Context) (models.Invoice, error) {
+	return LoadFromJSON(s.Filename)
+}
+
+// SourceForFile selects a Source implementation based on filename's
+// extension: ".csv" uses CSVSource, anything else uses JSONFileSource. This
+// is what cmd/generator uses to pick a source from DATA_FILE.
+func SourceForFile(filename string) Source {
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		return CSVSource{Filename: filename}
+	}
+	return JSONFileSource{Filename: filename}
+}
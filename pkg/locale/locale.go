@@ -0,0 +1,213 @@
+// Package locale supplies per-language defaults - labels, number/date
+// formatting conventions, and text direction - behind a registry keyed by
+// BCP-47 tag, so callers building an invoice don't have to hand-fill every
+// models.Labels field and formatting rule themselves.
+//
+// This is a separate, data-oriented registry from pkg/invoice's Locale
+// interface (which drives Builder.WithLocale and carries richer
+// behavior like JSON label bundles). pkg/locale exists so packages that
+// shouldn't depend on pkg/invoice - pkg/component's grid engine, in
+// particular - can still be locale-aware.
+package locale
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"bill-generator/pkg/models"
+)
+
+// Locale bundles the formatting conventions and default labels for one
+// language/region.
+type Locale struct {
+	// Code is the BCP-47 tag this locale is registered under (e.g. "ar-SA").
+	Code string
+	// Labels are the default label set for this locale.
+	Labels models.Labels
+	// IsRTL reports whether text in this locale should lay out right-to-left.
+	IsRTL bool
+	// DecimalSep and ThousandsSep are the glyphs FormatNumber separates the
+	// fractional part and thousands groups with.
+	DecimalSep   string
+	ThousandsSep string
+	// CurrencyBefore places a currency symbol before the amount (e.g.
+	// "$12.50") instead of after it (e.g. "12.50 SAR").
+	CurrencyBefore bool
+	// DateFormat is a Go time layout string (e.g. "02/01/2006").
+	DateFormat string
+	// FontFamily and FontFamilyBold name the TTF fonts (as registered with
+	// gopdf.AddTTFFont) a renderer should use for this locale's text, and
+	// its bold variant. Every built-in locale uses "Amiri"/"AmiriBold",
+	// the library's one bundled font, but a caller registering a locale
+	// for a script Amiri doesn't cover can point these at their own fonts.
+	FontFamily     string
+	FontFamilyBold string
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Locale{}
+)
+
+func init() {
+	Register(ArSA)
+	Register(EnUS)
+	Register(HeIL)
+	Register(PlPL)
+}
+
+// Register makes loc available under loc.Code, replacing any existing
+// registration for that code - so callers can override a built-in locale
+// with their own at runtime.
+func Register(loc Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[loc.Code] = loc
+}
+
+// Lookup returns the locale registered under code - an exact match against
+// every Register call (including the built-ins registered by init, which
+// all use region-qualified tags like "ar-SA"). There's no base-language
+// fallback: nothing is registered under a bare "ar" or "en" for it to fall
+// back to, so callers with a region-less or unsupported code should pick
+// an explicit registered Code themselves (see ArSA, EnUS, HeIL, PlPL).
+func Lookup(code string) (Locale, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	loc, ok := registry[code]
+	return loc, ok
+}
+
+// FormatNumber formats n with decimals fraction digits, grouping the
+// integer part and joining the fraction using loc's separators (e.g.
+// "1,234.56" for EnUS or "1٬234٫56" for ArSA).
+func FormatNumber(n float64, decimals int, loc Locale) string {
+	formatted := strconv.FormatFloat(n, 'f', decimals, 64)
+
+	neg := strings.HasPrefix(formatted, "-")
+	if neg {
+		formatted = formatted[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(formatted, ".")
+	out := groupThousands(intPart, loc.ThousandsSep)
+	if hasFrac {
+		out += loc.DecimalSep + fracPart
+	}
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+// FormatCurrency formats amount per FormatNumber and places symbol
+// before or after it according to loc.CurrencyBefore.
+func FormatCurrency(amount float64, decimals int, symbol string, loc Locale) string {
+	formatted := FormatNumber(amount, decimals, loc)
+	if symbol == "" {
+		return formatted
+	}
+	if loc.CurrencyBefore {
+		return symbol + formatted
+	}
+	return formatted + " " + symbol
+}
+
+// MergeLabels returns override with every zero-value field filled in from
+// base, so a caller only needs to set the labels they want to customize
+// and still gets a complete set (e.g. a partial JSON "labels" object
+// layered on top of a Locale's defaults).
+func MergeLabels(base, override models.Labels) models.Labels {
+	merged := override
+	if merged.InvoiceNumber == "" {
+		merged.InvoiceNumber = base.InvoiceNumber
+	}
+	if merged.Date == "" {
+		merged.Date = base.Date
+	}
+	if merged.VATRegistration == "" {
+		merged.VATRegistration = base.VATRegistration
+	}
+	if merged.TotalTaxable == "" {
+		merged.TotalTaxable = base.TotalTaxable
+	}
+	if merged.TotalWithVat == "" {
+		merged.TotalWithVat = base.TotalWithVat
+	}
+	if merged.ProductColumn == "" {
+		merged.ProductColumn = base.ProductColumn
+	}
+	if merged.QuantityColumn == "" {
+		merged.QuantityColumn = base.QuantityColumn
+	}
+	if merged.UnitPriceColumn == "" {
+		merged.UnitPriceColumn = base.UnitPriceColumn
+	}
+	if merged.DiscountColumn == "" {
+		merged.DiscountColumn = base.DiscountColumn
+	}
+	if merged.VATAmountColumn == "" {
+		merged.VATAmountColumn = base.VATAmountColumn
+	}
+	if merged.TotalColumn == "" {
+		merged.TotalColumn = base.TotalColumn
+	}
+	if merged.TotalDiscount == "" {
+		merged.TotalDiscount = base.TotalDiscount
+	}
+	if merged.Footer == "" {
+		merged.Footer = base.Footer
+	}
+	if merged.ContinuedOnNextPage == "" {
+		merged.ContinuedOnNextPage = base.ContinuedOnNextPage
+	}
+	if merged.CarriedForward == "" {
+		merged.CarriedForward = base.CarriedForward
+	}
+	if merged.CreditNoteBanner == "" {
+		merged.CreditNoteBanner = base.CreditNoteBanner
+	}
+	if merged.DebitNoteBanner == "" {
+		merged.DebitNoteBanner = base.DebitNoteBanner
+	}
+	if merged.ProformaBanner == "" {
+		merged.ProformaBanner = base.ProformaBanner
+	}
+	if merged.ReferencesInvoice == "" {
+		merged.ReferencesInvoice = base.ReferencesInvoice
+	}
+	if merged.PageCounter == "" {
+		merged.PageCounter = base.PageCounter
+	}
+	if merged.Issuer == "" {
+		merged.Issuer = base.Issuer
+	}
+	if merged.Customer == "" {
+		merged.Customer = base.Customer
+	}
+	if merged.DueDate == "" {
+		merged.DueDate = base.DueDate
+	}
+	if merged.IBAN == "" {
+		merged.IBAN = base.IBAN
+	}
+	if merged.SWIFT == "" {
+		merged.SWIFT = base.SWIFT
+	}
+	return merged
+}
+
+func groupThousands(digits, sep string) string {
+	if sep == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var groups []string
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+	return strings.Join(groups, sep)
+}
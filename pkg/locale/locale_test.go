@@ -0,0 +1,84 @@
+package locale
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		wantCode string
+		wantOK   bool
+	}{
+		{"exact match", "ar-SA", "ar-SA", true},
+		{"region-qualified but unregistered", "en-GB", "", false},
+		{"unregistered code", "fr-FR", "", false},
+		{"invalid BCP-47 tag", "???", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, ok := Lookup(tt.code)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup(%q) ok = %v, want %v", tt.code, ok, tt.wantOK)
+			}
+			if ok && loc.Code != tt.wantCode {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.code, loc.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRegisterOverridesBuiltin(t *testing.T) {
+	custom := EnUS
+	custom.DateFormat = "2006-01-02"
+	Register(custom)
+	defer Register(EnUS)
+
+	loc, ok := Lookup("en-US")
+	if !ok {
+		t.Fatal("Lookup(\"en-US\") ok = false, want true")
+	}
+	if loc.DateFormat != "2006-01-02" {
+		t.Errorf("DateFormat = %q, want %q", loc.DateFormat, "2006-01-02")
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		n    float64
+		loc  Locale
+		want string
+	}{
+		{"ar-SA groups and separates with Arabic glyphs", 1234.56, ArSA, "1٬234٫56"},
+		{"en-US groups and separates with Western glyphs", 1234.56, EnUS, "1,234.56"},
+		{"pl-PL groups with a space", 1234.56, PlPL, "1 234,56"},
+		{"negative amount keeps the sign in front", -1234.56, EnUS, "-1,234.56"},
+		{"no grouping needed under a thousand", 12.5, EnUS, "12.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatNumber(tt.n, 2, tt.loc); got != tt.want {
+				t.Errorf("FormatNumber(%v, 2, %s) = %q, want %q", tt.n, tt.loc.Code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  Locale
+		want string
+	}{
+		{"en-US places the symbol before the amount", EnUS, "$1,234.56"},
+		{"ar-SA places the symbol after the amount", ArSA, "1٬234٫56 ر.س"},
+	}
+	symbols := map[string]string{"en-US": "$", "ar-SA": "ر.س"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatCurrency(1234.56, 2, symbols[tt.loc.Code], tt.loc); got != tt.want {
+				t.Errorf("FormatCurrency(...) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package locale
+
+import "bill-generator/pkg/models"
+
+// ArSA is the built-in Arabic (Saudi Arabia) locale: RTL, Eastern Arabic
+// decimal/thousands separators, and currency printed after the amount.
+var ArSA = Locale{
+	Code: "ar-SA",
+	Labels: models.Labels{
+		InvoiceNumber:       "رقم الفاتورة:",
+		Date:                "التاريخ:",
+		VATRegistration:     "رقم التسجيل الضريبي:",
+		TotalTaxable:        "إجمالي المبلغ الخاضع للضريبة:",
+		TotalWithVat:        "الإجمالي شامل الضريبة:",
+		ProductColumn:       "المنتج",
+		QuantityColumn:      "الكمية",
+		UnitPriceColumn:     "سعر الوحدة",
+		DiscountColumn:      "الخصم",
+		VATAmountColumn:     "الضريبة",
+		TotalColumn:         "الإجمالي",
+		TotalDiscount:       "إجمالي الخصم:",
+		Footer:              "شكراً لتعاملكم معنا",
+		ContinuedOnNextPage: "يتبع في الصفحة التالية",
+		CarriedForward:      "ترحيل من الصفحة السابقة:",
+	},
+	IsRTL:          true,
+	DecimalSep:     "٫",
+	ThousandsSep:   "٬",
+	CurrencyBefore: false,
+	DateFormat:     "02/01/2006",
+	FontFamily:     "Amiri",
+	FontFamilyBold: "AmiriBold",
+}
+
+// EnUS is the built-in English (United States) locale.
+var EnUS = Locale{
+	Code: "en-US",
+	Labels: models.Labels{
+		InvoiceNumber:       "Invoice No:",
+		Date:                "Date:",
+		VATRegistration:     "VAT Registration No:",
+		TotalTaxable:        "Total Taxable Amount:",
+		TotalWithVat:        "Total with VAT:",
+		ProductColumn:       "Product",
+		QuantityColumn:      "Qty",
+		UnitPriceColumn:     "Unit Price",
+		DiscountColumn:      "Discount",
+		VATAmountColumn:     "VAT",
+		TotalColumn:         "Total",
+		TotalDiscount:       "Total Discount:",
+		Footer:              "Thank you for your business",
+		ContinuedOnNextPage: "Continued on next page",
+		CarriedForward:      "Carried forward:",
+	},
+	IsRTL:          false,
+	DecimalSep:     ".",
+	ThousandsSep:   ",",
+	CurrencyBefore: true,
+	DateFormat:     "01/02/2006",
+	FontFamily:     "Amiri",
+	FontFamilyBold: "AmiriBold",
+}
+
+// HeIL is the built-in Hebrew (Israel) locale: RTL with Western digits
+// and separators.
+var HeIL = Locale{
+	Code: "he-IL",
+	Labels: models.Labels{
+		InvoiceNumber:       ":מספר חשבונית",
+		Date:                ":תאריך",
+		VATRegistration:     ":מספר עוסק מורשה",
+		TotalTaxable:        ":סך הכל חייב במע\"מ",
+		TotalWithVat:        ":סך הכל כולל מע\"מ",
+		ProductColumn:       "מוצר",
+		QuantityColumn:      "כמות",
+		UnitPriceColumn:     "מחיר יחידה",
+		DiscountColumn:      "הנחה",
+		VATAmountColumn:     "מע\"מ",
+		TotalColumn:         "סך הכל",
+		TotalDiscount:       ":סך הנחות",
+		Footer:              "תודה שקניתם אצלנו",
+		ContinuedOnNextPage: "המשך בעמוד הבא",
+		CarriedForward:      ":הועבר מהעמוד הקודם",
+	},
+	IsRTL:          true,
+	DecimalSep:     ".",
+	ThousandsSep:   ",",
+	CurrencyBefore: false,
+	DateFormat:     "02/01/2006",
+	FontFamily:     "Amiri",
+	FontFamilyBold: "AmiriBold",
+}
+
+// PlPL is the built-in Polish locale.
+var PlPL = Locale{
+	Code: "pl-PL",
+	Labels: models.Labels{
+		InvoiceNumber:       "Nr faktury:",
+		Date:                "Data:",
+		VATRegistration:     "NIP:",
+		TotalTaxable:        "Suma netto:",
+		TotalWithVat:        "Suma brutto:",
+		ProductColumn:       "Produkt",
+		QuantityColumn:      "Ilość",
+		UnitPriceColumn:     "Cena jedn.",
+		DiscountColumn:      "Rabat",
+		VATAmountColumn:     "VAT",
+		TotalColumn:         "Razem",
+		TotalDiscount:       "Suma rabatów:",
+		Footer:              "Dziękujemy za zakupy",
+		ContinuedOnNextPage: "Ciąg dalszy na następnej stronie",
+		CarriedForward:      "Przeniesienie z poprzedniej strony:",
+	},
+	IsRTL:          false,
+	DecimalSep:     ",",
+	ThousandsSep:   " ",
+	CurrencyBefore: false,
+	DateFormat:     "02.01.2006",
+	FontFamily:     "Amiri",
+	FontFamilyBold: "AmiriBold",
+}
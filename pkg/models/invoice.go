@@ -1,94 +1,384 @@
-// Package models contains data structures for invoice generation.
-package models
-
-// Config holds global configuration for invoice generation.
-type Config struct {
-	VATPercentage  float64 `json:"vatPercentage"`
-	CurrencySymbol string  `json:"currencySymbol"`
-	DateFormat     string  `json:"dateFormat"`
-	English        bool    `json:"english"` // false (default) = Arabic RTL, true = English LTR
-}
-
-// ProductInput represents a product from JSON input.
-// All values are pre-calculated - this library only visualizes, no calculations.
-type ProductInput struct {
-	Name      string  `json:"name"`
-	Quantity  float64 `json:"quantity"`
-	UnitPrice float64 `json:"unitPrice"`
-	Discount  float64 `json:"discount,omitempty"` // Pre-calculated discount amount
-	VATAmount float64 `json:"vatAmount"`          // Pre-calculated VAT
-	Total     float64 `json:"total"`              // Pre-calculated total (inc. VAT)
-}
-
-// Product represents a single product line item for rendering.
-// All values are pre-calculated and passed directly from input.
-type Product struct {
-	Name      string
-	Quantity  float64
-	UnitPrice float64
-	Discount  float64 // Pre-calculated discount amount
-	VATAmount float64 // Pre-calculated VAT
-	Total     float64 // Pre-calculated total (inc. VAT)
-}
-
-// InvoiceInput represents invoice header data from JSON input.
-// All totals are pre-calculated - this library only visualizes.
-type InvoiceInput struct {
-	Title             string  `json:"title"`
-	InvoiceNumber     string  `json:"invoiceNumber"`
-	StoreName         string  `json:"storeName"`
-	StoreAddress      string  `json:"storeAddress"`
-	Date              string  `json:"date"`
-	VATRegistrationNo string  `json:"vatRegistrationNo"`
-	QRCodeData        string  `json:"qrCodeData"`
-	TotalDiscount     float64 `json:"totalDiscount,omitempty"`  // Pre-calculated total discount
-	TotalTaxable      float64 `json:"totalTaxable"`             // Pre-calculated taxable amount
-	TotalVAT          float64 `json:"totalVat"`                 // Pre-calculated total VAT
-	TotalWithVAT      float64 `json:"totalWithVat"`             // Pre-calculated grand total
-}
-
-// Labels holds all text labels for the invoice (supports i18n).
-type Labels struct {
-	InvoiceNumber   string `json:"invoiceNumber"`
-	Date            string `json:"date"`
-	VATRegistration string `json:"vatRegistration"`
-	TotalTaxable    string `json:"totalTaxable"`
-	TotalWithVat    string `json:"totalWithVat"`
-	ProductColumn   string `json:"productColumn"`
-	QuantityColumn  string `json:"quantityColumn"`
-	UnitPriceColumn string `json:"unitPriceColumn"`
-	DiscountColumn  string `json:"discountColumn,omitempty"`
-	VATAmountColumn string `json:"vatAmountColumn"`
-	TotalColumn     string `json:"totalColumn"`
-	TotalDiscount   string `json:"totalDiscount,omitempty"`
-	Footer          string `json:"footer"`
-}
-
-// InvoiceData represents the complete JSON input structure.
-type InvoiceData struct {
-	Config   Config         `json:"config"`
-	Invoice  InvoiceInput   `json:"invoice"`
-	Products []ProductInput `json:"products"`
-	Labels   Labels         `json:"labels"`
-}
-
-// Invoice represents a fully processed invoice ready for PDF generation.
-type Invoice struct {
-	Title             string
-	InvoiceNumber     string
-	StoreName         string
-	StoreAddress      string
-	Date              string
-	VATRegistrationNo string
-	Products          []Product
-	TotalGross        float64 // Sum of all gross amounts (before discounts)
-	TotalDiscount     float64 // Sum of all discounts
-	TotalTaxableAmt   float64 // Sum of net amounts (after discounts)
-	TotalVAT          float64
-	TotalWithVAT      float64
-	QRCodeData        string
-	VATPercentage     float64
-	Labels            Labels
-	Language          string // "ar" or "en"
-	IsRTL             bool   // true for Arabic/Hebrew, false for English
-}
+// Package models contains data structures for invoice generation.
+package models
+
+// Config holds global configuration for invoice generation.
+type Config struct {
+	VATPercentage  float64 `json:"vatPercentage"`
+	CurrencySymbol string  `json:"currencySymbol"`
+	DateFormat     string  `json:"dateFormat"`
+	English        bool    `json:"english"` // false (default) = Arabic RTL, true = English LTR
+	// PageSize selects the physical page size (e.g. "a4", "a5", "letter",
+	// "receipt"); empty keeps the generator's own default (a tall thermal
+	// receipt page). See pkg/pdf.PageSizeByName for the recognized names.
+	PageSize string `json:"pageSize,omitempty"`
+	// Locale selects a BCP-47 tag registered with pkg/locale (e.g.
+	// "pl-PL", "he-IL") to derive Language/IsRTL and fill in any Labels
+	// the caller left empty, beyond the plain English on/off switch.
+	// Takes precedence over English when both are set and the tag
+	// resolves; unrecognized tags fall back to the English flag.
+	Locale string `json:"locale,omitempty"`
+	// Conformance requests a PDF/A-3 conformance level ("pdfa3b" or
+	// "pdfa3u"); empty emits a plain PDF. See pkg/pdf.Generator's doc
+	// comment on Conformance support - github.com/signintech/gopdf has no
+	// public API for the embedded-file attachments, XMP metadata, or ICC
+	// OutputIntent a real PDF/A-3 file needs, so setting this currently
+	// makes Generate/GenerateBytes return an error rather than silently
+	// emit a non-conformant file.
+	Conformance string `json:"conformance,omitempty"`
+}
+
+// Attachment is a file embedded in the PDF as a PDF/A-3 "Source" AF
+// (associated file) - e.g. the ZATCA UBL or Peppol BIS XML a conformant
+// e-invoice archives alongside its human-readable rendering. See
+// Invoice.Attachments and Config.Conformance.
+type Attachment struct {
+	// Name is the attachment's filename within the PDF (e.g. "invoice.xml").
+	Name string
+	// MimeType is the attachment's declared MIME type (e.g. "text/xml").
+	MimeType string
+	// Data is the attachment's raw file content.
+	Data []byte
+}
+
+// AllowanceCharge is a single named allowance (discount) or charge applied
+// to a product line (via ProductInput.AllowanceCharges) or the invoice as a
+// whole (via InvoiceInput.AllowanceCharges), beyond the single anonymous
+// DiscountPercent/ChargePercent a line already supports. Percent and Amount
+// are additive, the same convention ProductInput's own discount/charge
+// fields use, so a caller can combine a percentage-of-gross discount with a
+// fixed one under a single reason.
+type AllowanceCharge struct {
+	// ChargeIndicator is false for an allowance (discount) and true for a
+	// charge (e.g. a delivery fee), matching the UBL ChargeIndicator flag.
+	ChargeIndicator bool    `json:"chargeIndicator,omitempty"`
+	Amount          float64 `json:"amount,omitempty"`
+	Percent         float64 `json:"percent,omitempty"` // Of the line's (or invoice's) gross/taxable amount
+	Reason          string  `json:"reason,omitempty"`
+}
+
+// ProductInput represents a product from JSON input.
+// Quantity and UnitPrice are the only inputs the caller must supply; the
+// discount, tax, and total amounts are derived by loader.BuildInvoice.
+type ProductInput struct {
+	Name            string  `json:"name"`
+	Quantity        float64 `json:"quantity"`
+	UnitPrice       float64 `json:"unitPrice"`
+	DiscountPercent float64 `json:"discountPercent,omitempty"` // Percentage discount on the gross amount
+	DiscountAmount  float64 `json:"discountAmount,omitempty"`  // Fixed discount amount, added to the percentage discount
+	ChargePercent   float64 `json:"chargePercent,omitempty"`   // Percentage charge on the gross amount (increases the net amount)
+	ChargeAmount    float64 `json:"chargeAmount,omitempty"`    // Fixed charge amount, added to the percentage charge
+	// AllowanceCharges lists additional named allowances/charges beyond the
+	// single anonymous DiscountPercent/DiscountAmount/ChargePercent/
+	// ChargeAmount above (e.g. a labeled "loyalty discount" and a labeled
+	// "delivery fee" on the same line); each is folded into the same
+	// NetAmount/VATAmount/TotalWithVAT calculation.
+	AllowanceCharges []AllowanceCharge `json:"allowanceCharges,omitempty"`
+	VATCategory      string            `json:"vatCategory,omitempty"` // e.g. "S" (standard), "Z" (zero-rated), "E" (exempt)
+	// VATRate overrides Config.VATPercentage for this line only, so an
+	// invoice can mix zero-rated, reduced-rate, and standard-rate items
+	// (e.g. 0%/5%/15%) in one document. A nil pointer (the zero value)
+	// means "not set" - the line falls back to Config.VATPercentage - and
+	// is distinct from an explicit 0 for a genuinely zero-rated item,
+	// unlike DiscountPercent/ChargePercent above, where 0 and "unset" are
+	// the same thing.
+	VATRate *float64 `json:"vatRate,omitempty"`
+	// PageBreakBefore forces a page break before this line when rendering,
+	// regardless of the renderer's page-break strategy (see pkg/pdf.WithPageBreakStrategy).
+	PageBreakBefore bool `json:"pageBreakBefore,omitempty"`
+}
+
+// Product represents a single product line item with all amounts derived
+// by loader.BuildInvoice and ready for rendering.
+type Product struct {
+	Name            string
+	Quantity        float64
+	UnitPrice       float64
+	DiscountPercent float64 // Percentage discount applied, as configured on input
+	DiscountAmount  float64 // Total discount amount (percentage + fixed + AllowanceCharges allowances)
+	ChargeAmount    float64 // Total charge amount (percentage + fixed + AllowanceCharges charges), added back on top of the discount
+	// AllowanceCharges carries AllowanceCharges through from ProductInput
+	// with each entry's Amount resolved to an absolute value (Percent
+	// applied against GrossAmount), for components that want to display
+	// them individually rather than only the summed DiscountAmount/
+	// ChargeAmount above.
+	AllowanceCharges []AllowanceCharge
+	GrossAmount      float64 // Quantity * UnitPrice, before discount/charge
+	NetAmount        float64 // GrossAmount - DiscountAmount + ChargeAmount
+	TaxableAmt       float64 // Amount VAT is calculated on (equal to NetAmount)
+	VATRate          float64 // Rate actually applied to this line - ProductInput.VATRate if set, else Config.VATPercentage
+	VATAmount        float64 // VAT calculated on NetAmount at VATRate
+	TotalWithVAT     float64 // NetAmount + VATAmount
+	VATCategory      string  // e.g. "S" (standard), "Z" (zero-rated), "E" (exempt)
+	PageBreakBefore  bool    // forces a page break before this line, as configured on input
+}
+
+// Customer represents the e-invoice recipient party. It's optional for
+// plain PDF rendering but required by zatca.MarshalUBL/MarshalZATCA to
+// populate the UBL AccountingCustomerParty block.
+type Customer struct {
+	Name      string `json:"name,omitempty"`
+	Address   string `json:"address,omitempty"`
+	VATNumber string `json:"vatNumber,omitempty"`
+	// CompanyNumber, Email, and Phone are additional contact details a
+	// customer party panel (e.g. component.PartyBlock) can display
+	// alongside Name/Address/VATNumber; zatca.MarshalUBL/MarshalZATCA
+	// ignore them, same as it ignores Contact's equivalents.
+	CompanyNumber string `json:"companyNumber,omitempty"`
+	Email         string `json:"email,omitempty"`
+	Phone         string `json:"phone,omitempty"`
+}
+
+// Contact represents a party's full contact details for the issuer block
+// pkg/pdf draws above the products table (see Invoice.Issuer). It's a
+// superset of Customer's single Name/Address/VATNumber, since a letterhead
+// typically needs a multi-line address, city/postal code, and a way to
+// reach the issuer; Customer is left as-is since zatca.MarshalUBL/
+// MarshalZATCA only ever need its three fields.
+type Contact struct {
+	Name         string   `json:"name,omitempty"`
+	TaxID        string   `json:"taxId,omitempty"` // VAT identification number
+	AddressLines []string `json:"addressLines,omitempty"`
+	City         string   `json:"city,omitempty"`
+	PostalCode   string   `json:"postalCode,omitempty"`
+	// CompanyNumber is a commercial/trade registration number, distinct
+	// from TaxID.
+	CompanyNumber  string   `json:"companyNumber,omitempty"`
+	Phone          string   `json:"phone,omitempty"`
+	Email          string   `json:"email,omitempty"`
+	AdditionalInfo []string `json:"additionalInfo,omitempty"`
+}
+
+// IsEmpty reports whether every field of c is unset, so callers can skip
+// drawing a panel for it entirely (a slice field makes the zero-value
+// struct itself non-comparable with ==).
+func (c Contact) IsEmpty() bool {
+	return c.Name == "" && c.TaxID == "" && len(c.AddressLines) == 0 &&
+		c.City == "" && c.PostalCode == "" && c.CompanyNumber == "" &&
+		c.Phone == "" && c.Email == "" && len(c.AdditionalInfo) == 0
+}
+
+// PaymentTerms groups the payment-info row pkg/pdf draws above the totals
+// block (see InvoiceInput.Payment) under a single "payment" JSON key,
+// instead of InvoiceInput's older flat DueDate/IBAN/SWIFT fields - which
+// remain as fallbacks for existing callers that already set them.
+type PaymentTerms struct {
+	DueDate   string `json:"dueDate,omitempty"`
+	IBAN      string `json:"iban,omitempty"`
+	SWIFT     string `json:"swift,omitempty"`
+	Reference string `json:"reference,omitempty"` // e.g. a payment/remittance reference code
+}
+
+// InvoiceType distinguishes a standard invoice from a credit note, debit
+// note, or proforma invoice. It controls whether negative quantities are
+// allowed (see invoice.InvoiceLineBuilder.Add) and which banner pkg/pdf
+// draws on the rendered document.
+type InvoiceType string
+
+const (
+	InvoiceTypeStandard InvoiceType = "" // zero value; treated as a standard invoice
+	InvoiceTypeCredit   InvoiceType = "credit"
+	InvoiceTypeDebit    InvoiceType = "debit"
+	InvoiceTypeProforma InvoiceType = "proforma"
+)
+
+// DocumentRef references another invoice, as required when a credit or
+// debit note must identify the original document it adjusts (UBL's
+// BillingReference/InvoiceDocumentReference block).
+type DocumentRef struct {
+	InvoiceNumber string `json:"invoiceNumber"`
+	IssueDate     string `json:"issueDate"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// InvoiceInput represents invoice header data from JSON input.
+// All totals are pre-calculated - this library only visualizes.
+type InvoiceInput struct {
+	Title             string   `json:"title"`
+	InvoiceNumber     string   `json:"invoiceNumber"`
+	StoreName         string   `json:"storeName"`
+	StoreAddress      string   `json:"storeAddress"`
+	Date              string   `json:"date"`
+	VATRegistrationNo string   `json:"vatRegistrationNo"`
+	QRCodeData        string   `json:"qrCodeData"`
+	Customer          Customer `json:"customer,omitempty"`
+	// Type marks the document as a credit note, debit note, or proforma
+	// invoice instead of a standard one; the zero value is InvoiceTypeStandard.
+	Type InvoiceType `json:"type,omitempty"`
+	// PrecedingReferences identifies the original invoice(s) this document
+	// adjusts, required on credit/debit notes.
+	PrecedingReferences []DocumentRef `json:"precedingReferences,omitempty"`
+	TotalDiscount       float64       `json:"totalDiscount,omitempty"` // Pre-calculated total discount
+	TotalTaxable        float64       `json:"totalTaxable"`            // Pre-calculated taxable amount
+	TotalVAT            float64       `json:"totalVat"`                // Pre-calculated total VAT
+	TotalWithVAT        float64       `json:"totalWithVat"`            // Pre-calculated grand total
+	LogoPath            string        `json:"logoPath,omitempty"`      // Path to a logo image drawn above the title
+	LogoWidth           float64       `json:"logoWidth,omitempty"`
+	LogoHeight          float64       `json:"logoHeight,omitempty"`
+	StampPath           string        `json:"stampPath,omitempty"` // Path to a stamp/watermark image
+	StampX              float64       `json:"stampX,omitempty"`    // Absolute page X position
+	StampY              float64       `json:"stampY,omitempty"`    // Absolute page Y position
+	// Compliance selects an e-invoicing regime to apply automatically when
+	// the invoice is loaded/generated; "zatca" triggers the Saudi ZATCA
+	// Phase-2 QR/UBL wiring in loader.BuildInvoice and pdf.Generator.Generate
+	// (see pkg/zatca). Empty means no regime-specific processing.
+	Compliance string `json:"compliance,omitempty"`
+	// InvoiceCounter is the ICV (invoice counter value) ZATCA requires as a
+	// monotonically increasing sequence number across an establishment's
+	// invoices. The caller is responsible for persisting and incrementing it
+	// between invoices; this library only threads it through.
+	InvoiceCounter int `json:"invoiceCounter,omitempty"`
+	// PreviousInvoiceHash is the PIH (previous invoice hash): the base64
+	// SHA-256 hash of the prior invoice's canonicalized UBL XML, as returned
+	// in Invoice.InvoiceHash. Leave empty on the first invoice in a chain;
+	// pkg/zatca's UBL builder seeds it with base64(SHA256("0")) in that case.
+	PreviousInvoiceHash string `json:"previousInvoiceHash,omitempty"`
+	// Issuer carries the store's full contact details for the letterhead
+	// block pkg/pdf draws above the products table. Leave it zero to keep
+	// the StoreName/StoreAddress-only header existing receipts already use.
+	Issuer Contact `json:"issuer,omitempty"`
+	// DueDate, IBAN, and SWIFT feed the payment-info row pkg/pdf draws
+	// above the totals block; each is omitted from that row when empty.
+	// Payment, under the "payment" JSON key, is the preferred way to set
+	// them (plus Reference); loader.BuildInvoice falls back to these flat
+	// fields for any of Payment's fields left empty.
+	DueDate string       `json:"dueDate,omitempty"`
+	IBAN    string       `json:"iban,omitempty"`
+	SWIFT   string       `json:"swift,omitempty"`
+	Payment PaymentTerms `json:"payment,omitempty"`
+	// AllowanceCharges lists invoice-level allowances/charges (e.g. a
+	// whole-order loyalty discount or a shipping charge), applied on top of
+	// every product line's own DiscountAmount/ChargeAmount/AllowanceCharges.
+	// Percent is taken against the taxable amount after line-level
+	// allowances/charges (Invoice.Totals.Taxable before this adjustment).
+	AllowanceCharges []AllowanceCharge `json:"allowanceCharges,omitempty"`
+	// Attachments lists files to embed as PDF/A-3 associated files when
+	// Config.Conformance is set - see Attachment and Config.Conformance.
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Labels holds all text labels for the invoice (supports i18n).
+type Labels struct {
+	InvoiceNumber   string `json:"invoiceNumber"`
+	Date            string `json:"date"`
+	VATRegistration string `json:"vatRegistration"`
+	TotalTaxable    string `json:"totalTaxable"`
+	TotalWithVat    string `json:"totalWithVat"`
+	ProductColumn   string `json:"productColumn"`
+	QuantityColumn  string `json:"quantityColumn"`
+	UnitPriceColumn string `json:"unitPriceColumn"`
+	DiscountColumn  string `json:"discountColumn,omitempty"`
+	VATAmountColumn string `json:"vatAmountColumn"`
+	TotalColumn     string `json:"totalColumn"`
+	TotalDiscount   string `json:"totalDiscount,omitempty"`
+	Footer          string `json:"footer"`
+	// ContinuedOnNextPage is the footer shown on a page whose products
+	// table overflows onto the next page (see pkg/pdf.WithPageBreakStrategy).
+	ContinuedOnNextPage string `json:"continuedOnNextPage,omitempty"`
+	// CarriedForward labels the running-subtotal row repeated at the top
+	// of the products table on every page after the first.
+	CarriedForward string `json:"carriedForward,omitempty"`
+	// CreditNoteBanner, DebitNoteBanner, and ProformaBanner are printed in
+	// place of the invoice title's document-type implication when
+	// Invoice.Type is set accordingly (see pkg/pdf's drawDocumentTypeBanner).
+	CreditNoteBanner string `json:"creditNoteBanner,omitempty"`
+	DebitNoteBanner  string `json:"debitNoteBanner,omitempty"`
+	ProformaBanner   string `json:"proformaBanner,omitempty"`
+	// ReferencesInvoice formats a preceding-invoice reference line; it
+	// receives the referenced invoice number and issue date via fmt.Sprintf
+	// (e.g. "References invoice %s dated %s").
+	ReferencesInvoice string `json:"referencesInvoice,omitempty"`
+	// PageCounter formats the per-page "page N of M" footer printed by
+	// pkg/pdf; it receives the current page number and total page count via
+	// fmt.Sprintf (e.g. "Page %d of %d"). Leave empty to omit the counter.
+	PageCounter string `json:"pageCounter,omitempty"`
+	// Issuer and Customer label the two contact panels pkg/pdf's
+	// drawIssuerCustomerBlocks draws above the products table.
+	Issuer   string `json:"issuer,omitempty"`
+	Customer string `json:"customer,omitempty"`
+	// DueDate, IBAN, SWIFT, and PaymentReference label the payment-info row
+	// pkg/pdf's drawPaymentInfo draws above the totals block.
+	DueDate          string `json:"dueDate,omitempty"`
+	IBAN             string `json:"iban,omitempty"`
+	SWIFT            string `json:"swift,omitempty"`
+	PaymentReference string `json:"paymentReference,omitempty"`
+}
+
+// Totals breaks the invoice's grand total down by stage of the allowance/
+// charge calculation, for components that want to show more than just the
+// final TotalWithVAT (e.g. a line itemizing the invoice-level discount).
+// Total always equals Invoice.TotalWithVAT.
+type Totals struct {
+	Gross           float64 // Sum of every line's GrossAmount
+	LineDiscount    float64 // Sum of every line's DiscountAmount
+	LineCharge      float64 // Sum of every line's ChargeAmount
+	InvoiceDiscount float64 // Sum of invoice-level allowances (InvoiceInput.AllowanceCharges)
+	InvoiceCharge   float64 // Sum of invoice-level charges (InvoiceInput.AllowanceCharges)
+	Taxable         float64 // Amount VAT is calculated on, after every line- and invoice-level adjustment
+	VAT             float64
+	Total           float64 // Taxable + VAT
+}
+
+// InvoiceData represents the complete JSON input structure.
+type InvoiceData struct {
+	Config   Config         `json:"config"`
+	Invoice  InvoiceInput   `json:"invoice"`
+	Products []ProductInput `json:"products"`
+	Labels   Labels         `json:"labels"`
+}
+
+// Invoice represents a fully processed invoice ready for PDF generation.
+type Invoice struct {
+	Title               string
+	InvoiceNumber       string
+	StoreName           string
+	StoreAddress        string
+	Date                string
+	VATRegistrationNo   string
+	Customer            Customer
+	Type                InvoiceType
+	PrecedingReferences []DocumentRef
+	Products            []Product
+	TotalGross          float64 // Sum of all gross amounts (before discounts)
+	TotalDiscount       float64 // Sum of all discounts
+	TotalTaxableAmt     float64 // Sum of net amounts (after discounts)
+	TotalVAT            float64
+	TotalWithVAT        float64
+	QRCodeData          string
+	VATPercentage       float64
+	Labels              Labels
+	Language            string // "ar" or "en"
+	IsRTL               bool   // true for Arabic/Hebrew, false for English
+	LogoPath            string // Path to a logo image drawn above the title, if any
+	LogoWidth           float64
+	LogoHeight          float64
+	StampPath           string // Path to a stamp/watermark image, if any
+	StampX              float64
+	StampY              float64
+	Compliance          string // e-invoicing regime to apply automatically, e.g. "zatca" - see InvoiceInput.Compliance
+	InvoiceCounter      int    // ZATCA ICV - see InvoiceInput.InvoiceCounter
+	PreviousInvoiceHash string // ZATCA PIH - see InvoiceInput.PreviousInvoiceHash
+	// InvoiceHash is the base64 SHA-256 hash of this invoice's canonicalized
+	// UBL XML, set by pkg/zatca after marshaling/signing. Feed it into the
+	// next invoice's PreviousInvoiceHash to keep the PIH chain intact; this
+	// library doesn't persist it itself.
+	InvoiceHash string
+	// PageSize carries Config.PageSize through to pkg/pdf.Generator, which
+	// resolves it via pkg/pdf.PageSizeByName.
+	PageSize string
+	// Issuer, DueDate, IBAN, and SWIFT - see InvoiceInput's fields of the
+	// same name.
+	Issuer  Contact
+	DueDate string
+	IBAN    string
+	SWIFT   string
+	// PaymentReference is InvoiceInput.Payment.Reference, drawn alongside
+	// DueDate/IBAN/SWIFT in the payment-info row.
+	PaymentReference string
+	// Totals breaks TotalWithVAT down by calculation stage - see models.Totals.
+	Totals Totals
+	// Conformance - see Config.Conformance.
+	Conformance string
+	// Attachments lists files to embed as PDF/A-3 associated files when
+	// Conformance is set (e.g. the source ZATCA UBL or Peppol BIS XML).
+	Attachments []Attachment
+}
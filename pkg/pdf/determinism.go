@@ -0,0 +1,25 @@
+package pdf
+
+import "time"
+
+// WithFixedTimestamp seeds the PDF's /CreationDate with t instead of the
+// real render time, so two renders of the same invoice produce
+// byte-identical output - useful for golden-file regression tests (see
+// CompareGolden) and for batch.WriteManifest's sha256 to stay stable across
+// reruns.
+func WithFixedTimestamp(t time.Time) Option {
+	return func(g *Generator) {
+		g.fixedTimestamp = t
+	}
+}
+
+// WithFixedDocID seeds a fixed document identifier into the PDF's /Producer
+// field instead of leaving it blank. gopdf v0.34.0 only writes a real /ID
+// trailer entry when encryption is enabled (see its xref/writeInfo), so
+// /Producer is the closest stand-in its public API exposes for a
+// deterministic per-document ID.
+func WithFixedDocID(id string) Option {
+	return func(g *Generator) {
+		g.fixedDocID = id
+	}
+}
@@ -3,10 +3,14 @@ package pdf
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"bill-generator/pkg/models"
 	"bill-generator/pkg/textutil"
+	"bill-generator/pkg/zatca"
 
 	"github.com/signintech/gopdf"
 	"github.com/skip2/go-qrcode"
@@ -14,36 +18,248 @@ import (
 
 // Generator handles PDF generation for invoices.
 type Generator struct {
-	pdf      gopdf.GoPdf
-	fontDir  string
-	invoice  models.Invoice
-	pageW    float64
-	margin   float64
-	contentW float64
-	currentY float64
+	pdf                                              gopdf.GoPdf
+	fontDir                                          string
+	invoice                                          models.Invoice
+	pageSize                                         PageSize
+	marginTop, marginRight, marginBottom, marginLeft float64
+	contentW                                         float64
+	currentY                                         float64
+	pageBreakStrategy                                PageBreakStrategy
+	pageNum                                          int
+	totalPages                                       int
+
+	// Generator-level logo/stamp, used when the invoice itself doesn't
+	// specify one (see models.Invoice.LogoPath/StampPath).
+	logoPath       string
+	logoW, logoH   float64
+	stampPath      string
+	stampX, stampY float64
+
+	// cert, when set, makes drawQRCode embed a Phase-2 signed QR (via
+	// zatca.Sign) instead of the Phase-1/simplified invoice.QRCodeData -
+	// see WithCert.
+	cert *zatca.Cert
+
+	// fixedTimestamp/fixedDocID enable deterministic output for golden-file
+	// tests - see WithFixedTimestamp/WithFixedDocID.
+	fixedTimestamp time.Time
+	fixedDocID     string
+}
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// Orientation selects how a PageSize's width and height are applied.
+type Orientation int
+
+const (
+	OrientationPortrait  Orientation = iota // width/height as given (default)
+	OrientationLandscape                    // width/height swapped
+)
+
+// WithOrientation sets the page orientation (default OrientationPortrait).
+// It swaps the Generator's current PageSize's width and height, so apply it
+// after WithPageSize if both are given as Options to NewGenerator.
+func WithOrientation(o Orientation) Option {
+	return func(g *Generator) {
+		if o == OrientationLandscape {
+			g.pageSize.Width, g.pageSize.Height = g.pageSize.Height, g.pageSize.Width
+		}
+	}
+}
+
+// WithPageSize sets the physical page size (default PageSizeThermal80mm).
+func WithPageSize(ps PageSize) Option {
+	return func(g *Generator) {
+		g.pageSize = ps
+	}
+}
+
+// WithMargins sets the page margins (default 10pt on every side).
+func WithMargins(top, right, bottom, left float64) Option {
+	return func(g *Generator) {
+		g.marginTop = top
+		g.marginRight = right
+		g.marginBottom = bottom
+		g.marginLeft = left
+	}
+}
+
+// WithLogo sets a default logo image, drawn centered above the invoice
+// title, for invoices that don't set their own via Builder.WithLogo.
+func WithLogo(path string, w, h float64) Option {
+	return func(g *Generator) {
+		g.logoPath = path
+		g.logoW = w
+		g.logoH = h
+	}
+}
+
+// WithStamp sets a default stamp/watermark image drawn at the given
+// absolute page coordinates, for invoices that don't set their own via
+// Builder.WithStamp.
+func WithStamp(path string, x, y float64) Option {
+	return func(g *Generator) {
+		g.stampPath = path
+		g.stampX = x
+		g.stampY = y
+	}
+}
+
+// WithCert sets the ZATCA Phase-2 signing cert drawQRCode uses to embed a
+// signed QR (via zatca.Sign) in place of invoice.QRCodeData. Invoices
+// without Compliance == "zatca" are unaffected; invoices with it but no
+// WithCert fall back to the Phase-1/simplified QR, same as Generate's doc
+// comment describes.
+func WithCert(cert zatca.Cert) Option {
+	return func(g *Generator) {
+		g.cert = &cert
+	}
 }
 
 // NewGenerator creates a new PDF generator.
-func NewGenerator(fontDir string) *Generator {
-	return &Generator{
-		fontDir: fontDir,
-		pageW:   226.77, // 80mm in points
-		margin:  10.0,
+func NewGenerator(fontDir string, opts ...Option) *Generator {
+	g := &Generator{
+		fontDir:  fontDir,
+		pageSize: PageSizeThermal80mm,
 	}
+	g.marginTop, g.marginRight, g.marginBottom, g.marginLeft = 10.0, 10.0, 10.0, 10.0
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
-// Generate creates a PDF from the invoice and saves it to filename.
+// Generate creates a PDF from the invoice and saves it to filename. When
+// invoice.Compliance is "zatca", it also writes the ZATCA UBL 2.1 XML
+// representation next to filename (same path with its extension replaced
+// by ".xml") - see writeZATCAXML. Without a WithCert signing key, the
+// embedded QR only carries the Phase-1/simplified fields (tags 1-5); with
+// one, drawQRCode embeds the Phase-2 signed QR instead (see WithCert).
 func (g *Generator) Generate(invoice models.Invoice, filename string) error {
+	if err := checkConformance(invoice); err != nil {
+		return err
+	}
+	totalPages, err := g.countPages(invoice)
+	if err != nil {
+		return err
+	}
+	if err := g.render(invoice, totalPages); err != nil {
+		return err
+	}
+	if err := g.pdf.WritePdf(filename); err != nil {
+		return err
+	}
+	if invoice.Compliance == "zatca" {
+		return writeZATCAXML(invoice, filename)
+	}
+	return nil
+}
+
+// writeZATCAXML marshals invoice as a ZATCA UBL 2.1 document and writes it
+// next to pdfFilename, with the extension replaced by ".xml".
+func writeZATCAXML(invoice models.Invoice, pdfFilename string) error {
+	xmlData, err := zatca.MarshalZATCA(invoice, zatca.ZATCAOptions{Timestamp: invoice.Date})
+	if err != nil {
+		return fmt.Errorf("failed to marshal ZATCA XML: %w", err)
+	}
+
+	xmlFilename := strings.TrimSuffix(pdfFilename, ".pdf") + ".xml"
+	return os.WriteFile(xmlFilename, xmlData, 0644)
+}
+
+// GenerateBytes renders the invoice and returns the PDF content without
+// touching the filesystem, for callers that want to stream it elsewhere
+// (e.g. an HTTP response).
+func (g *Generator) GenerateBytes(invoice models.Invoice) ([]byte, error) {
+	if err := checkConformance(invoice); err != nil {
+		return nil, err
+	}
+	totalPages, err := g.countPages(invoice)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.render(invoice, totalPages); err != nil {
+		return nil, err
+	}
+	return g.pdf.GetBytesPdfReturnErr()
+}
+
+// GenerateTo renders the invoice and writes the PDF content directly to w,
+// without buffering the whole file in memory first (unlike GenerateBytes)
+// or touching the filesystem - for HTTP handlers that want to stream a
+// response body. It does not write the ZATCA UBL XML sidecar that Generate
+// does; callers that need it should call zatca.MarshalZATCA themselves.
+func (g *Generator) GenerateTo(invoice models.Invoice, w io.Writer) error {
+	if err := checkConformance(invoice); err != nil {
+		return err
+	}
+	totalPages, err := g.countPages(invoice)
+	if err != nil {
+		return err
+	}
+	if err := g.render(invoice, totalPages); err != nil {
+		return err
+	}
+	return g.pdf.Write(w)
+}
+
+// checkConformance rejects invoice.Conformance rather than silently emitting
+// a non-conformant file. PDF/A-3 requires embedding the invoice's source XML
+// as an AF (associated file) plus XMP metadata and an ICC OutputIntent, but
+// github.com/signintech/gopdf (the library Generator is built on) has no
+// public API for any of the three - only an unexported addObj touches raw
+// PDF objects. Producing a real PDF/A-3 file would mean forking gopdf or
+// splicing raw PDF bytes after the fact, which is out of scope here; this
+// returns a clear error instead of quietly shipping a plain PDF mislabeled
+// as conformant. See models.Config.Conformance and models.Invoice.Attachments.
+func checkConformance(invoice models.Invoice) error {
+	if invoice.Conformance == "" {
+		return nil
+	}
+	return fmt.Errorf("pdf: conformance %q requested but not supported - gopdf has no public API for PDF/A-3 attachments, XMP metadata, or ICC OutputIntent", invoice.Conformance)
+}
+
+// countPages runs a throwaway render pass to find out how many pages
+// invoice needs, so the real pass (see render) can print an accurate
+// "page N of M" footer from the very first page instead of only M.
+func (g *Generator) countPages(invoice models.Invoice) (int, error) {
+	if err := g.render(invoice, 0); err != nil {
+		return 0, err
+	}
+	return g.pdf.GetNumberOfPages(), nil
+}
+
+// render builds the PDF document for invoice into g.pdf, ready to be
+// written out by Generate or GenerateBytes. totalPages is the page count
+// to print in the footer's "page N of M" counter; pass 0 when it isn't
+// known yet (see countPages).
+func (g *Generator) render(invoice models.Invoice, totalPages int) error {
 	g.invoice = invoice
-	g.contentW = g.pageW - (2 * g.margin)
-	g.currentY = 10.0
+	if invoice.PageSize != "" {
+		if ps, ok := PageSizeByName(invoice.PageSize); ok {
+			g.pageSize = ps
+		}
+	}
+	g.contentW = g.pageSize.Width - g.marginLeft - g.marginRight
+	g.currentY = g.marginTop
+	g.pageNum = 1
+	g.totalPages = totalPages
 
 	// Initialize PDF
 	g.pdf = gopdf.GoPdf{}
 	g.pdf.Start(gopdf.Config{
-		PageSize: gopdf.Rect{W: 226.77, H: 708.66}, // 80mm x 250mm
+		PageSize: gopdf.Rect{W: g.pageSize.Width, H: g.pageSize.Height},
 	})
 
+	if !g.fixedTimestamp.IsZero() || g.fixedDocID != "" {
+		g.pdf.SetInfo(gopdf.PdfInfo{
+			CreationDate: g.fixedTimestamp,
+			Producer:     g.fixedDocID,
+		})
+	}
+
 	// Load fonts
 	if err := g.loadFonts(); err != nil {
 		return err
@@ -52,14 +268,32 @@ func (g *Generator) Generate(invoice models.Invoice, filename string) error {
 	g.pdf.AddPage()
 
 	// Draw invoice sections
+	g.drawLogo()
 	g.drawHeader()
+	g.drawDocumentTypeBanner()
 	g.drawInvoiceInfo()
+	g.drawIssuerCustomerBlocks()
 	g.drawProductsTable()
+	g.drawPaymentInfo()
 	g.drawTotals()
 	g.drawFooter()
 	g.drawQRCode()
+	g.drawStamp()
+	g.drawPageFooterCounter()
 
-	return g.pdf.WritePdf(filename)
+	return nil
+}
+
+// GenerateInvoice is a convenience wrapper around NewGenerator(fontDir).Generate,
+// for callers that don't need to reuse a Generator across invoices.
+func GenerateInvoice(invoice models.Invoice, outputPath, fontDir string) error {
+	return NewGenerator(fontDir).Generate(invoice, outputPath)
+}
+
+// GenerateInvoiceBytes is a convenience wrapper around
+// NewGenerator(fontDir).GenerateBytes.
+func GenerateInvoiceBytes(invoice models.Invoice, fontDir string) ([]byte, error) {
+	return NewGenerator(fontDir).GenerateBytes(invoice)
 }
 
 func (g *Generator) loadFonts() error {
@@ -82,10 +316,47 @@ func (g *Generator) drawHeader() {
 		g.pdf.SetFont("Amiri", "", 14)
 	}
 	g.pdf.SetTextColor(0, 0, 0)
-	textutil.DrawTextCentered(&g.pdf, g.invoice.Title, g.margin, g.currentY+4, g.contentW, g.invoice.IsRTL)
+	textutil.DrawTextCentered(&g.pdf, g.invoice.Title, g.marginLeft, g.currentY+4, g.contentW, g.invoice.IsRTL)
 	g.currentY += 18
 }
 
+// drawDocumentTypeBanner prints the document-type label (credit note, debit
+// note, or proforma invoice) below the title, plus a preceding-invoice
+// reference line for credit/debit notes. It draws nothing for a standard
+// invoice.
+func (g *Generator) drawDocumentTypeBanner() {
+	inv := g.invoice
+
+	var banner string
+	switch inv.Type {
+	case models.InvoiceTypeCredit:
+		banner = inv.Labels.CreditNoteBanner
+	case models.InvoiceTypeDebit:
+		banner = inv.Labels.DebitNoteBanner
+	case models.InvoiceTypeProforma:
+		banner = inv.Labels.ProformaBanner
+	default:
+		return
+	}
+	if banner == "" {
+		return
+	}
+
+	if err := g.pdf.SetFont("AmiriBold", "", 11); err != nil {
+		g.pdf.SetFont("Amiri", "", 11)
+	}
+	textutil.DrawTextCentered(&g.pdf, banner, g.marginLeft, g.currentY, g.contentW, inv.IsRTL)
+	g.currentY += 14
+
+	if len(inv.PrecedingReferences) > 0 && inv.Labels.ReferencesInvoice != "" {
+		ref := inv.PrecedingReferences[0]
+		g.pdf.SetFont("Amiri", "", 9)
+		refText := fmt.Sprintf(inv.Labels.ReferencesInvoice, ref.InvoiceNumber, ref.IssueDate)
+		textutil.DrawTextCentered(&g.pdf, refText, g.marginLeft, g.currentY, g.contentW, inv.IsRTL)
+		g.currentY += 12
+	}
+}
+
 func (g *Generator) drawInvoiceInfo() {
 	inv := g.invoice
 	isRTL := inv.IsRTL
@@ -98,15 +369,15 @@ func (g *Generator) drawInvoiceInfo() {
 	labelW, _ := g.pdf.MeasureTextWidth(labelText)
 
 	if isRTL {
-		g.pdf.SetXY(g.margin+g.contentW-labelW-3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+g.contentW-labelW-3, g.currentY)
 		g.pdf.Cell(nil, labelText)
-		g.pdf.SetXY(g.margin+3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+3, g.currentY)
 		g.pdf.Cell(nil, inv.InvoiceNumber)
 	} else {
-		g.pdf.SetXY(g.margin+3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+3, g.currentY)
 		g.pdf.Cell(nil, labelText)
 		valueW, _ := g.pdf.MeasureTextWidth(inv.InvoiceNumber)
-		g.pdf.SetXY(g.margin+g.contentW-valueW-3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+g.contentW-valueW-3, g.currentY)
 		g.pdf.Cell(nil, inv.InvoiceNumber)
 	}
 	g.currentY += 12
@@ -115,12 +386,12 @@ func (g *Generator) drawInvoiceInfo() {
 	if err := g.pdf.SetFont("AmiriBold", "", 11); err != nil {
 		g.pdf.SetFont("Amiri", "", 11)
 	}
-	textutil.DrawTextCentered(&g.pdf, inv.StoreName, g.margin, g.currentY, g.contentW, isRTL)
+	textutil.DrawTextCentered(&g.pdf, inv.StoreName, g.marginLeft, g.currentY, g.contentW, isRTL)
 	g.currentY += 14
 
 	// Store Address
 	g.pdf.SetFont("Amiri", "", 9)
-	textutil.DrawTextCentered(&g.pdf, inv.StoreAddress, g.margin, g.currentY, g.contentW, isRTL)
+	textutil.DrawTextCentered(&g.pdf, inv.StoreAddress, g.marginLeft, g.currentY, g.contentW, isRTL)
 	g.currentY += 14
 
 	// Date
@@ -128,15 +399,15 @@ func (g *Generator) drawInvoiceInfo() {
 	dateLabelW, _ := g.pdf.MeasureTextWidth(dateLabel)
 
 	if isRTL {
-		g.pdf.SetXY(g.margin+g.contentW-dateLabelW-3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+g.contentW-dateLabelW-3, g.currentY)
 		g.pdf.Cell(nil, dateLabel)
-		g.pdf.SetXY(g.margin+3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+3, g.currentY)
 		g.pdf.Cell(nil, inv.Date)
 	} else {
-		g.pdf.SetXY(g.margin+3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+3, g.currentY)
 		g.pdf.Cell(nil, dateLabel)
 		dateW, _ := g.pdf.MeasureTextWidth(inv.Date)
-		g.pdf.SetXY(g.margin+g.contentW-dateW-3, g.currentY)
+		g.pdf.SetXY(g.marginLeft+g.contentW-dateW-3, g.currentY)
 		g.pdf.Cell(nil, inv.Date)
 	}
 	g.currentY += 12
@@ -147,31 +418,169 @@ func (g *Generator) drawInvoiceInfo() {
 	vatLabelW, _ := g.pdf.MeasureTextWidth(vatLabel)
 
 	if isRTL {
-		g.pdf.SetXY(g.margin+g.contentW-vatLabelW, g.currentY)
+		g.pdf.SetXY(g.marginLeft+g.contentW-vatLabelW, g.currentY)
 		g.pdf.Cell(nil, vatLabel)
-		g.pdf.SetXY(g.margin, g.currentY)
+		g.pdf.SetXY(g.marginLeft, g.currentY)
 		g.pdf.Cell(nil, inv.VATRegistrationNo)
 	} else {
-		g.pdf.SetXY(g.margin, g.currentY)
+		g.pdf.SetXY(g.marginLeft, g.currentY)
 		g.pdf.Cell(nil, vatLabel)
 		vatNoW, _ := g.pdf.MeasureTextWidth(inv.VATRegistrationNo)
-		g.pdf.SetXY(g.margin+g.contentW-vatNoW, g.currentY)
+		g.pdf.SetXY(g.marginLeft+g.contentW-vatNoW, g.currentY)
 		g.pdf.Cell(nil, inv.VATRegistrationNo)
 	}
 	g.currentY += 14
 }
 
-func (g *Generator) drawProductsTable() {
+// contactBlockHeight is a conservative estimate of the issuer/customer
+// panels' height, used by ensureSpace to decide whether they need to start
+// on a fresh page.
+const contactBlockHeight = 60.0
+
+// drawIssuerCustomerBlocks draws the issuer's and customer's contact
+// details side by side, the issuer in Invoice.Issuer and the customer in
+// Invoice.Customer. Either panel is skipped when its contact is empty, and
+// the whole block is skipped when both are, so existing receipts that
+// don't set them are unaffected.
+func (g *Generator) drawIssuerCustomerBlocks() {
+	inv := g.invoice
+	issuerEmpty := inv.Issuer.IsEmpty()
+	customerEmpty := inv.Customer == (models.Customer{})
+	if issuerEmpty && customerEmpty {
+		return
+	}
+	g.ensureSpace(contactBlockHeight)
+
+	isRTL := inv.IsRTL
+	panelWidth := g.contentW / 2
+	leftX, rightX := g.marginLeft, g.marginLeft+panelWidth
+	issuerX, customerX := leftX, rightX
+	if isRTL {
+		issuerX, customerX = rightX, leftX
+	}
+	startY := g.currentY
+
+	issuerEnd := startY
+	if !issuerEmpty {
+		issuerEnd = g.drawContactPanel(inv.Labels.Issuer, issuerX, panelWidth, startY, isRTL, contactLines(inv.Issuer))
+	}
+	customerEnd := startY
+	if !customerEmpty {
+		customerEnd = g.drawContactPanel(inv.Labels.Customer, customerX, panelWidth, startY, isRTL, []string{inv.Customer.Name, inv.Customer.Address, inv.Customer.VATNumber})
+	}
+
+	g.currentY = issuerEnd
+	if customerEnd > g.currentY {
+		g.currentY = customerEnd
+	}
+	g.currentY += 8
+}
+
+// drawContactPanel draws one labeled panel of non-empty lines starting at
+// (x, y), width wide, and returns the Y position just below its last line.
+func (g *Generator) drawContactPanel(label string, x, panelWidth, y float64, isRTL bool, lines []string) float64 {
+	if err := g.pdf.SetFont("AmiriBold", "", 9); err != nil {
+		g.pdf.SetFont("Amiri", "", 9)
+	}
+	g.pdf.SetTextColor(0, 0, 0)
+	if label != "" {
+		textutil.DrawTextLeft(&g.pdf, label, x, y, isRTL)
+		y += 12
+	}
+
+	g.pdf.SetFont("Amiri", "", 8)
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		y += textutil.DrawWrappedText(&g.pdf, line, x, y, panelWidth-4, 10, isRTL)
+	}
+	return y
+}
+
+// contactLines flattens a Contact into the ordered lines drawContactPanel
+// prints, skipping the ones the caller left empty.
+func contactLines(c models.Contact) []string {
+	lines := []string{c.Name}
+	lines = append(lines, c.AddressLines...)
+	cityLine := strings.TrimSpace(strings.TrimSuffix(c.PostalCode+" "+c.City, " "))
+	if cityLine != "" {
+		lines = append(lines, cityLine)
+	}
+	if c.TaxID != "" {
+		lines = append(lines, c.TaxID)
+	}
+	if c.CompanyNumber != "" {
+		lines = append(lines, c.CompanyNumber)
+	}
+	if c.Phone != "" {
+		lines = append(lines, c.Phone)
+	}
+	if c.Email != "" {
+		lines = append(lines, c.Email)
+	}
+	lines = append(lines, c.AdditionalInfo...)
+	return lines
+}
+
+// paymentInfoBlockHeight is a conservative estimate of the payment-info
+// row's height, used by ensureSpace to decide whether it needs to start on
+// a fresh page.
+const paymentInfoBlockHeight = 14.0
+
+// drawPaymentInfo draws a single row of due-date/IBAN/SWIFT/reference
+// details above the totals block, omitting whichever are empty and
+// skipping the row entirely when all of them are, so existing receipts
+// are unaffected.
+func (g *Generator) drawPaymentInfo() {
 	inv := g.invoice
+	if inv.DueDate == "" && inv.IBAN == "" && inv.SWIFT == "" && inv.PaymentReference == "" {
+		return
+	}
+	g.ensureSpace(paymentInfoBlockHeight)
+
 	isRTL := inv.IsRTL
+	g.pdf.SetFont("Amiri", "", 8)
+	g.pdf.SetTextColor(0, 0, 0)
+
+	var parts []string
+	if inv.DueDate != "" {
+		parts = append(parts, fmt.Sprintf("%s %s", inv.Labels.DueDate, inv.DueDate))
+	}
+	if inv.IBAN != "" {
+		parts = append(parts, fmt.Sprintf("%s %s", inv.Labels.IBAN, inv.IBAN))
+	}
+	if inv.SWIFT != "" {
+		parts = append(parts, fmt.Sprintf("%s %s", inv.Labels.SWIFT, inv.SWIFT))
+	}
+	if inv.PaymentReference != "" {
+		parts = append(parts, fmt.Sprintf("%s %s", inv.Labels.PaymentReference, inv.PaymentReference))
+	}
 
-	// Column widths (order depends on RTL)
-	// For RTL: Total, VAT, Price, Qty, Product (right to left visually)
-	// For LTR: Product, Qty, Price, VAT, Total (left to right visually)
+	textutil.DrawTextLeft(&g.pdf, strings.Join(parts, "    "), g.marginLeft, g.currentY, isRTL)
+	g.currentY += paymentInfoBlockHeight
+}
+
+func (g *Generator) drawProductsTable() {
+	inv := g.invoice
+	isRTL := inv.IsRTL
+	showDiscount := inv.Labels.DiscountColumn != ""
+
+	// Column widths (order depends on RTL). A Discount column is inserted
+	// only when the invoice supplies a DiscountColumn label (same condition
+	// pkg/htmlrender's Table uses), taking its width from the Product
+	// column so invoices without allowances/charges keep today's layout.
+	// For RTL: Total, VAT, [Discount], Price, Qty, Product (right to left visually)
+	// For LTR: Product, Qty, Price, [Discount], VAT, Total (left to right visually)
 	var colWidths []float64
-	if isRTL {
+	switch {
+	case isRTL && showDiscount:
+		colWidths = []float64{30, 30, 40, 30, 20, 56}
+	case isRTL:
 		colWidths = []float64{30, 30, 30, 20, 96}
-	} else {
+	case showDiscount:
+		colWidths = []float64{56, 20, 30, 40, 30, 30}
+	default:
 		colWidths = []float64{96, 20, 30, 30, 30}
 	}
 
@@ -179,18 +588,16 @@ func (g *Generator) drawProductsTable() {
 	for _, w := range colWidths {
 		tableWidth += w
 	}
-	tableX := g.margin
+	tableX := g.marginLeft
 
 	// Draw header
-	g.drawTableHeader(tableX, colWidths, isRTL)
+	g.drawTableHeader(tableX, colWidths, isRTL, showDiscount)
 
 	// Draw rows
 	g.drawTableRows(tableX, colWidths, isRTL)
 }
 
-func (g *Generator) drawTableHeader(tableX float64, colWidths []float64, isRTL bool) {
-	inv := g.invoice
-
+func (g *Generator) drawTableHeader(tableX float64, colWidths []float64, isRTL bool, showDiscount bool) {
 	g.pdf.SetStrokeColor(0, 0, 0)
 	g.pdf.SetLineWidth(0.5)
 
@@ -209,23 +616,35 @@ func (g *Generator) drawTableHeader(tableX float64, colWidths []float64, isRTL b
 	}
 	g.pdf.SetTextColor(0, 0, 0)
 
+	discountHeader := []string{"", g.invoice.Labels.DiscountColumn}
+
 	var headers [][]string
 	if isRTL {
 		headers = [][]string{
 			{"السعر شامل", "الضريبة"},
 			{"ضريبة القيمة", "المضافة"},
-			{"سعر", "الوحدة"},
-			{"", "الكمية"},
-			{"", "المنتجات"},
 		}
+		if showDiscount {
+			headers = append(headers, discountHeader)
+		}
+		headers = append(headers,
+			[]string{"سعر", "الوحدة"},
+			[]string{"", "الكمية"},
+			[]string{"", "المنتجات"},
+		)
 	} else {
 		headers = [][]string{
 			{"", "Product"},
 			{"", "Qty"},
 			{"Unit", "Price"},
-			{"VAT", "Amount"},
-			{"Total", "(inc. VAT)"},
 		}
+		if showDiscount {
+			headers = append(headers, discountHeader)
+		}
+		headers = append(headers,
+			[]string{"VAT", "Amount"},
+			[]string{"Total", "(inc. VAT)"},
+		)
 	}
 
 	xPos = tableX
@@ -248,20 +667,96 @@ func (g *Generator) drawTableHeader(tableX float64, colWidths []float64, isRTL b
 	g.currentY += headerHeight
 }
 
+// continuedFooterHeight is the vertical space reserved at the bottom of a
+// page for the "Continued on next page" notice when a row doesn't fit.
+const continuedFooterHeight = 14.0
+
+// printableBottom is the Y coordinate below which nothing may be drawn
+// without breaking the page.
+func (g *Generator) printableBottom() float64 {
+	return g.pageSize.Height - g.marginBottom
+}
+
+// pageBandHeight is the vertical space reserved at the top of a
+// continuation page for drawContinuationHeaderBand.
+const pageBandHeight = 24.0
+
+// startNewPage closes out the current page's "page N of M" footer, starts a
+// new page, and draws the repeating store-name/invoice-number header band,
+// leaving currentY ready for the caller to resume drawing below it. Used
+// wherever content would otherwise overflow the page (see breakTablePage
+// and ensureSpace).
+func (g *Generator) startNewPage() {
+	g.drawPageFooterCounter()
+	g.pdf.AddPage()
+	g.pageNum++
+	g.drawContinuationHeaderBand()
+}
+
+// drawContinuationHeaderBand draws a compact store-name/invoice-number band
+// at the top of a continuation page, standing in for the full title/logo/
+// invoice-info block that only the first page carries.
+func (g *Generator) drawContinuationHeaderBand() {
+	inv := g.invoice
+
+	if err := g.pdf.SetFont("AmiriBold", "", 9); err != nil {
+		g.pdf.SetFont("Amiri", "", 9)
+	}
+	g.pdf.SetTextColor(0, 0, 0)
+	textutil.DrawTextCentered(&g.pdf, inv.StoreName, g.marginLeft, g.marginTop, g.contentW, inv.IsRTL)
+
+	g.pdf.SetFont("Amiri", "", 8)
+	label := textutil.ProcessText(inv.Labels.InvoiceNumber, inv.IsRTL)
+	text := label + " " + inv.InvoiceNumber
+	if inv.IsRTL {
+		text = inv.InvoiceNumber + " " + label
+	}
+	textutil.DrawTextCentered(&g.pdf, text, g.marginLeft, g.marginTop+12, g.contentW, inv.IsRTL)
+
+	g.currentY = g.marginTop + pageBandHeight
+}
+
+// drawPageFooterCounter prints Labels.PageCounter (e.g. "Page %d of %d")
+// near the bottom of the current page. It's a no-op when the label isn't
+// set. totalPages is 0 during the measuring pass (see countPages); that
+// pass's output is discarded, so the placeholder count never reaches a
+// reader.
+func (g *Generator) drawPageFooterCounter() {
+	if g.invoice.Labels.PageCounter == "" {
+		return
+	}
+	g.pdf.SetFont("Amiri", "", 7)
+	g.pdf.SetTextColor(0, 0, 0)
+	text := fmt.Sprintf(g.invoice.Labels.PageCounter, g.pageNum, g.totalPages)
+	textutil.DrawTextCentered(&g.pdf, text, g.marginLeft, g.printableBottom()-10, g.contentW, g.invoice.IsRTL)
+}
+
+// ensureSpace starts a new page if the next block of the given height
+// wouldn't fit above printableBottom.
+func (g *Generator) ensureSpace(height float64) {
+	if g.currentY+height > g.printableBottom() {
+		g.startNewPage()
+	}
+}
+
 func (g *Generator) drawTableRows(tableX float64, colWidths []float64, isRTL bool) {
 	inv := g.invoice
 
 	g.pdf.SetFont("Amiri", "", 9)
 	baseRowHeight := 12.0
 	minRowHeight := 18.0
+	runningTotal := 0.0
+	rowsOnPage := 0
 
 	for _, product := range inv.Products {
 		g.pdf.SetStrokeColor(0, 0, 0)
 
-		// Calculate row height based on product name wrapping
+		// Calculate row height based on product name wrapping - the
+		// Product column is always last in an RTL layout, first in LTR,
+		// regardless of whether a Discount column is also present.
 		var productColIdx int
 		if isRTL {
-			productColIdx = 4
+			productColIdx = len(colWidths) - 1
 		} else {
 			productColIdx = 0
 		}
@@ -271,6 +766,17 @@ func (g *Generator) drawTableRows(tableX float64, colWidths []float64, isRTL boo
 			rowHeight = minRowHeight
 		}
 
+		// A manual break always applies (except at the very top of a page,
+		// where it would be a no-op); an automatic break only kicks in when
+		// the row wouldn't fit in the remaining space.
+		manualBreak := product.PageBreakBefore && rowsOnPage > 0
+		autoBreak := g.pageBreakStrategy == PageBreakAuto && rowsOnPage > 0 &&
+			g.currentY+rowHeight+continuedFooterHeight > g.printableBottom()
+		if manualBreak || autoBreak {
+			g.breakTablePage(tableX, colWidths, isRTL, runningTotal)
+			rowsOnPage = 0
+		}
+
 		// Draw row cell borders
 		xPos := tableX
 		for i := range colWidths {
@@ -289,13 +795,76 @@ func (g *Generator) drawTableRows(tableX float64, colWidths []float64, isRTL boo
 		}
 
 		g.currentY += rowHeight
+		runningTotal += product.TotalWithVAT
+		rowsOnPage++
 	}
 
 	g.currentY += 8
 }
 
+// breakTablePage closes out the products table on the current page with a
+// "Continued on next page" notice, starts a new page, re-emits the table
+// header, and carries the running subtotal forward as the first row.
+func (g *Generator) breakTablePage(tableX float64, colWidths []float64, isRTL bool, runningTotal float64) {
+	g.pdf.SetFont("Amiri", "", 8)
+	g.pdf.SetTextColor(0, 0, 0)
+	textutil.DrawTextCentered(&g.pdf, g.invoice.Labels.ContinuedOnNextPage, g.marginLeft, g.currentY, g.contentW, isRTL)
+
+	g.startNewPage()
+
+	g.drawTableHeader(tableX, colWidths, isRTL, len(colWidths) == 6)
+	g.drawCarriedForwardRow(tableX, colWidths, runningTotal)
+}
+
+// drawCarriedForwardRow draws a single full-width row stating the running
+// subtotal brought over from the previous page(s).
+func (g *Generator) drawCarriedForwardRow(tableX float64, colWidths []float64, runningTotal float64) {
+	tableWidth := 0.0
+	for _, w := range colWidths {
+		tableWidth += w
+	}
+
+	rowHeight := 16.0
+	g.pdf.SetStrokeColor(0, 0, 0)
+	g.pdf.SetLineWidth(0.5)
+	g.pdf.RectFromUpperLeftWithStyle(tableX, g.currentY, tableWidth, rowHeight, "D")
+
+	if err := g.pdf.SetFont("AmiriBold", "", 8); err != nil {
+		g.pdf.SetFont("Amiri", "", 8)
+	}
+	g.pdf.SetTextColor(0, 0, 0)
+	text := fmt.Sprintf("%s %.1f", g.invoice.Labels.CarriedForward, runningTotal)
+	g.pdf.SetXY(tableX+3, g.currentY+4)
+	g.pdf.Cell(nil, textutil.ProcessText(text, g.invoice.IsRTL))
+
+	g.currentY += rowHeight
+}
+
+// allowanceChargeSummary renders a product's named allowances/charges as a
+// single "reason -amount; reason +amount" cell, or "" when there are none -
+// the same rendering pkg/htmlrender's Table uses for its Discount column.
+func allowanceChargeSummary(acs []models.AllowanceCharge) string {
+	if len(acs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(acs))
+	for i, ac := range acs {
+		sign := "-"
+		if ac.ChargeIndicator {
+			sign = "+"
+		}
+		if ac.Reason != "" {
+			parts[i] = fmt.Sprintf("%s %s%.2f", ac.Reason, sign, ac.Amount)
+		} else {
+			parts[i] = fmt.Sprintf("%s%.2f", sign, ac.Amount)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
 func (g *Generator) drawRowCellsRTL(tableX float64, colWidths []float64, textY, lineHeight float64, product models.Product) {
 	xPos := tableX
+	showDiscount := len(colWidths) == 6
 
 	// Column 0: Total with VAT
 	totalStr := fmt.Sprintf("%.1f", product.TotalWithVAT)
@@ -311,26 +880,40 @@ func (g *Generator) drawRowCellsRTL(tableX float64, colWidths []float64, textY,
 	g.pdf.Cell(nil, vatStr)
 	xPos += colWidths[1]
 
-	// Column 2: Unit Price
+	col := 2
+	if showDiscount {
+		// Column 2: Discount/allowance summary
+		discStr := textutil.ProcessText(allowanceChargeSummary(product.AllowanceCharges), true)
+		dw, _ := g.pdf.MeasureTextWidth(discStr)
+		g.pdf.SetXY(xPos+colWidths[2]-dw-3, textY)
+		g.pdf.Cell(nil, discStr)
+		xPos += colWidths[2]
+		col = 3
+	}
+
+	// Unit Price
 	priceStr := fmt.Sprintf("%.0f", product.UnitPrice)
 	pw, _ := g.pdf.MeasureTextWidth(priceStr)
-	g.pdf.SetXY(xPos+colWidths[2]-pw-3, textY)
+	g.pdf.SetXY(xPos+colWidths[col]-pw-3, textY)
 	g.pdf.Cell(nil, priceStr)
-	xPos += colWidths[2]
+	xPos += colWidths[col]
+	col++
 
-	// Column 3: Quantity
+	// Quantity
 	qtyStr := fmt.Sprintf("%.0f", product.Quantity)
 	qw, _ := g.pdf.MeasureTextWidth(qtyStr)
-	g.pdf.SetXY(xPos+colWidths[3]-qw-3, textY)
+	g.pdf.SetXY(xPos+colWidths[col]-qw-3, textY)
 	g.pdf.Cell(nil, qtyStr)
-	xPos += colWidths[3]
+	xPos += colWidths[col]
+	col++
 
-	// Column 4: Product Name
-	textutil.DrawWrappedText(&g.pdf, product.Name, xPos, textY, colWidths[4], lineHeight, true)
+	// Product Name, always the last column in RTL
+	textutil.DrawWrappedText(&g.pdf, product.Name, xPos, textY, colWidths[col], lineHeight, true)
 }
 
 func (g *Generator) drawRowCellsLTR(tableX float64, colWidths []float64, textY, lineHeight float64, product models.Product) {
 	xPos := tableX
+	showDiscount := len(colWidths) == 6
 
 	// Column 0: Product Name
 	textutil.DrawWrappedText(&g.pdf, product.Name, xPos, textY, colWidths[0], lineHeight, false)
@@ -350,29 +933,78 @@ func (g *Generator) drawRowCellsLTR(tableX float64, colWidths []float64, textY,
 	g.pdf.Cell(nil, priceStr)
 	xPos += colWidths[2]
 
-	// Column 3: VAT Amount
+	col := 3
+	if showDiscount {
+		// Column 3: Discount/allowance summary
+		discStr := allowanceChargeSummary(product.AllowanceCharges)
+		dw, _ := g.pdf.MeasureTextWidth(discStr)
+		g.pdf.SetXY(xPos+colWidths[3]-dw-3, textY)
+		g.pdf.Cell(nil, discStr)
+		xPos += colWidths[3]
+		col = 4
+	}
+
+	// VAT Amount
 	vatStr := fmt.Sprintf("%.1f", product.VATAmount)
 	vw, _ := g.pdf.MeasureTextWidth(vatStr)
-	g.pdf.SetXY(xPos+colWidths[3]-vw-3, textY)
+	g.pdf.SetXY(xPos+colWidths[col]-vw-3, textY)
 	g.pdf.Cell(nil, vatStr)
-	xPos += colWidths[3]
+	xPos += colWidths[col]
+	col++
 
-	// Column 4: Total with VAT
+	// Total with VAT
 	totalStr := fmt.Sprintf("%.1f", product.TotalWithVAT)
 	tw, _ := g.pdf.MeasureTextWidth(totalStr)
-	g.pdf.SetXY(xPos+colWidths[4]-tw-3, textY)
+	g.pdf.SetXY(xPos+colWidths[col]-tw-3, textY)
 	g.pdf.Cell(nil, totalStr)
 }
 
+// totalsBlockHeight is a conservative estimate of the totals block's
+// height, used by ensureSpace to decide whether it needs to start on a
+// fresh page. Sized for the Taxable Amount and Total with VAT rows plus
+// the optional Total Discount row drawTotals adds above them.
+const totalsBlockHeight = 56.0
+
 func (g *Generator) drawTotals() {
+	g.ensureSpace(totalsBlockHeight)
+
 	inv := g.invoice
 	isRTL := inv.IsRTL
 
 	tableWidth := 206.0
-	totalsX := g.margin
+	totalsX := g.marginLeft
 	valueWidth := 40.0
 	labelWidth := tableWidth - valueWidth
 
+	// Row 0: Total Discount, shown only when there's a discount to report
+	// and the invoice supplies a TotalDiscount label - mirrors
+	// pkg/htmlrender's Totals().
+	if discount := inv.Totals.LineDiscount + inv.Totals.InvoiceDiscount; discount > 0 && inv.Labels.TotalDiscount != "" {
+		g.pdf.SetStrokeColor(0, 0, 0)
+		g.pdf.SetLineWidth(0.5)
+		g.pdf.RectFromUpperLeftWithStyle(totalsX, g.currentY, valueWidth, 16, "D")
+		g.pdf.RectFromUpperLeftWithStyle(totalsX+valueWidth, g.currentY, labelWidth, 16, "D")
+
+		g.pdf.SetFont("Amiri", "", 9)
+		g.pdf.SetTextColor(0, 0, 0)
+
+		discountStr := fmt.Sprintf("%.0f", discount)
+		discountW, _ := g.pdf.MeasureTextWidth(discountStr)
+		g.pdf.SetXY(totalsX+valueWidth-discountW-3, g.currentY+3)
+		g.pdf.Cell(nil, discountStr)
+
+		discountLbl := textutil.ProcessText(inv.Labels.TotalDiscount, isRTL)
+		discountLblW, _ := g.pdf.MeasureTextWidth(discountLbl)
+
+		if isRTL {
+			g.pdf.SetXY(totalsX+valueWidth+labelWidth-discountLblW-2, g.currentY)
+		} else {
+			g.pdf.SetXY(totalsX+valueWidth+3, g.currentY)
+		}
+		g.pdf.Cell(nil, discountLbl)
+		g.currentY += 16
+	}
+
 	// Row 1: Taxable Amount
 	g.pdf.SetStrokeColor(0, 0, 0)
 	g.pdf.SetLineWidth(0.5)
@@ -432,20 +1064,76 @@ func (g *Generator) drawTotals() {
 	g.currentY += 22
 }
 
+// footerBlockHeight is a conservative estimate of the footer line's height,
+// used by ensureSpace to decide whether it needs to start on a fresh page.
+const footerBlockHeight = 12.0
+
 func (g *Generator) drawFooter() {
+	g.ensureSpace(footerBlockHeight)
 	g.pdf.SetFont("Amiri", "", 7)
 	g.pdf.SetTextColor(0, 0, 0)
-	textutil.DrawTextCentered(&g.pdf, g.invoice.Labels.Footer, g.margin, g.currentY, g.contentW, g.invoice.IsRTL)
+	textutil.DrawTextCentered(&g.pdf, g.invoice.Labels.Footer, g.marginLeft, g.currentY, g.contentW, g.invoice.IsRTL)
 	g.currentY += 12
 }
 
+// qrBlockHeight is a conservative estimate of the QR code block's height,
+// used by ensureSpace to decide whether it needs to start on a fresh page.
+const qrBlockHeight = 65.0
+
 func (g *Generator) drawQRCode() {
-	qrFile := "/tmp/temp_qr.png"
-	err := qrcode.WriteFile(g.invoice.QRCodeData, qrcode.High, 256, qrFile)
+	qrData := g.qrData()
+	if qrData == "" {
+		return
+	}
+	g.ensureSpace(qrBlockHeight)
+
+	qr, err := qrcode.New(qrData, qrcode.High)
 	if err == nil {
 		qrSize := 55.0
-		qrX := g.margin + (g.contentW-qrSize)/2
-		g.pdf.Image(qrFile, qrX, g.currentY, &gopdf.Rect{W: qrSize, H: qrSize})
-		os.Remove(qrFile)
+		qrX := g.marginLeft + (g.contentW-qrSize)/2
+		g.pdf.ImageFrom(qr.Image(256), qrX, g.currentY, &gopdf.Rect{W: qrSize, H: qrSize})
+	}
+}
+
+// qrData returns the QR payload drawQRCode embeds: the Phase-2 signed QR
+// from zatca.Sign when a WithCert key is configured for a ZATCA-compliant
+// invoice, falling back to the invoice's own Phase-1/simplified
+// QRCodeData (set by loader.BuildInvoice via zatca.BuildQR, or by a caller
+// directly) when signing isn't available or fails.
+func (g *Generator) qrData() string {
+	if g.cert != nil && g.invoice.Compliance == "zatca" {
+		if signed, err := zatca.Sign(g.invoice, *g.cert, g.invoice.Date); err == nil {
+			return signed.QR
+		}
+	}
+	return g.invoice.QRCodeData
+}
+
+// drawLogo draws the invoice's logo, if set, centered above the title. The
+// invoice's own LogoPath takes precedence over the Generator's default.
+func (g *Generator) drawLogo() {
+	path, w, h := g.invoice.LogoPath, g.invoice.LogoWidth, g.invoice.LogoHeight
+	if path == "" {
+		path, w, h = g.logoPath, g.logoW, g.logoH
+	}
+	if path == "" {
+		return
+	}
+	x := g.marginLeft + (g.contentW-w)/2
+	g.pdf.Image(path, x, g.currentY, &gopdf.Rect{W: w, H: h})
+	g.currentY += h + 6
+}
+
+// drawStamp draws the invoice's stamp/watermark, if set, at its absolute
+// page coordinates. The invoice's own StampPath takes precedence over the
+// Generator's default. Drawn last so it's unaffected by currentY.
+func (g *Generator) drawStamp() {
+	path, x, y := g.invoice.StampPath, g.invoice.StampX, g.invoice.StampY
+	if path == "" {
+		path, x, y = g.stampPath, g.stampX, g.stampY
+	}
+	if path == "" {
+		return
 	}
+	g.pdf.Image(path, x, y, nil)
 }
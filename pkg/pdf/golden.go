@@ -0,0 +1,37 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// CompareGolden compares the file at path against the checked-in reference
+// PDF at want, byte for byte, returning a descriptive error at the first
+// difference (or a length mismatch). Pair it with WithFixedTimestamp and
+// WithFixedDocID so the Generator output under test is actually
+// reproducible - without those, a fresh /CreationDate makes every render
+// differ from the golden file even when nothing meaningful changed.
+func CompareGolden(path, want string) error {
+	got, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("pdf: read %s: %w", path, err)
+	}
+	wantBytes, err := os.ReadFile(want)
+	if err != nil {
+		return fmt.Errorf("pdf: read golden file %s: %w", want, err)
+	}
+
+	if bytes.Equal(got, wantBytes) {
+		return nil
+	}
+	if len(got) != len(wantBytes) {
+		return fmt.Errorf("pdf: %s does not match golden file %s: %d bytes, want %d bytes", path, want, len(got), len(wantBytes))
+	}
+	for i := range got {
+		if got[i] != wantBytes[i] {
+			return fmt.Errorf("pdf: %s does not match golden file %s: first difference at byte %d", path, want, i)
+		}
+	}
+	return nil
+}
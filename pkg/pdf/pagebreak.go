@@ -0,0 +1,23 @@
+package pdf
+
+// PageBreakStrategy controls how the Generator paginates the products
+// table when it doesn't fit on a single page.
+type PageBreakStrategy int
+
+const (
+	// PageBreakAuto inserts a page break whenever the next row would
+	// overflow the printable area (the default).
+	PageBreakAuto PageBreakStrategy = iota
+	// PageBreakManual only breaks the page where a product has
+	// models.Product.PageBreakBefore set, ignoring overflow.
+	PageBreakManual
+)
+
+// WithPageBreakStrategy sets how the products table is paginated (default
+// PageBreakAuto). Regardless of the strategy, a product with
+// PageBreakBefore set always starts a new page.
+func WithPageBreakStrategy(s PageBreakStrategy) Option {
+	return func(g *Generator) {
+		g.pageBreakStrategy = s
+	}
+}
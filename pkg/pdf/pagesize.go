@@ -0,0 +1,40 @@
+package pdf
+
+import "strings"
+
+// PageSize describes a page's physical dimensions in points (1/72 inch).
+type PageSize struct {
+	Name   string
+	Width  float64
+	Height float64
+}
+
+// Predefined page sizes. Thermal sizes default to a generous height since
+// receipt printers cut the page to the content rather than a fixed length.
+var (
+	PageSizeA4          = PageSize{Name: "A4", Width: 595.28, Height: 841.89}
+	PageSizeLetter      = PageSize{Name: "Letter", Width: 612, Height: 792}
+	PageSizeA5          = PageSize{Name: "A5", Width: 419.53, Height: 595.28}
+	PageSizeThermal80mm = PageSize{Name: "Thermal-80mm", Width: 226.77, Height: 708.66}
+	PageSizeThermal58mm = PageSize{Name: "Thermal-58mm", Width: 164.41, Height: 708.66}
+)
+
+// pageSizesByName resolves the Config.PageSize JSON value (case-insensitive)
+// to a PageSize, for callers that configure the generator from JSON rather
+// than WithPageSize.
+var pageSizesByName = map[string]PageSize{
+	"a4":          PageSizeA4,
+	"letter":      PageSizeLetter,
+	"a5":          PageSizeA5,
+	"receipt":     PageSizeThermal80mm,
+	"thermal80mm": PageSizeThermal80mm,
+	"thermal58mm": PageSizeThermal58mm,
+}
+
+// PageSizeByName looks up a PageSize by its Config.PageSize name (e.g. "a4",
+// "a5", "letter", "receipt"), case-insensitive. It reports false for an
+// unrecognized name.
+func PageSizeByName(name string) (PageSize, bool) {
+	ps, ok := pageSizesByName[strings.ToLower(name)]
+	return ps, ok
+}
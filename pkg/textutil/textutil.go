@@ -18,7 +18,10 @@ func ProcessText(text string, isRTL bool) string {
 }
 
 // WrapText splits text into multiple lines that fit within maxWidth.
-// Returns the lines and the total height needed.
+// Returns the lines and the total height needed. For RTL text, every
+// line but the last is stretched to fill maxWidth with kashida (see
+// arabictext.Justify) instead of being left ragged, matching how proper
+// Arabic typesetting justifies full lines.
 func WrapText(pdf *gopdf.GoPdf, text string, maxWidth float64, lineHeight float64, isRTL bool) ([]string, float64) {
 	processedText := ProcessText(text, isRTL)
 
@@ -28,32 +31,31 @@ func WrapText(pdf *gopdf.GoPdf, text string, maxWidth float64, lineHeight float6
 		return []string{processedText}, lineHeight
 	}
 
-	// Need to wrap - split by characters
-	var lines []string
-	runes := []rune(text)
+	// Need to wrap - pack words (see arabictext.WrapShaped) rather than
+	// growing a line one rune at a time.
+	rawLines := arabictext.WrapShaped(text, func(s string) float64 {
+		w, _ := pdf.MeasureTextWidth(s)
+		return w
+	}, maxWidth)
 
-	currentLine := ""
-	for i := 0; i < len(runes); i++ {
-		testLine := currentLine + string(runes[i])
-		testProcessed := ProcessText(testLine, isRTL)
-		testWidth, _ := pdf.MeasureTextWidth(testProcessed)
-
-		if testWidth > maxWidth && currentLine != "" {
-			// Current line is full, save it and start new line
-			lines = append(lines, ProcessText(currentLine, isRTL))
-			currentLine = string(runes[i])
-		} else {
-			currentLine = testLine
-		}
+	if len(rawLines) == 0 {
+		return []string{processedText}, lineHeight
 	}
 
-	// Add the last line
-	if currentLine != "" {
-		lines = append(lines, ProcessText(currentLine, isRTL))
+	measure := func(r rune) float64 {
+		w, _ := pdf.MeasureTextWidth(string(r))
+		return w
 	}
 
-	if len(lines) == 0 {
-		lines = []string{processedText}
+	lines := make([]string, len(rawLines))
+	for i, raw := range rawLines {
+		processed := ProcessText(raw, isRTL)
+		if isRTL && i < len(rawLines)-1 {
+			width, _ := pdf.MeasureTextWidth(processed)
+			lines[i] = arabictext.JustifyText(raw, width, maxWidth, measure)
+		} else {
+			lines[i] = processed
+		}
 	}
 
 	return lines, float64(len(lines)) * lineHeight
@@ -0,0 +1,111 @@
+package zatca
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+
+	"bill-generator/pkg/models"
+)
+
+// Cert carries the ECDSA P-256 signing key a Phase-2 invoice is hashed and
+// signed with, plus the CSID stamp signature ZATCA issues over the
+// certificate's public key (tag 9 of the QR TLV). Leave StampSignature nil
+// if it isn't available yet; the QR is still built, just without that tag.
+type Cert struct {
+	PrivateKey     *ecdsa.PrivateKey
+	StampSignature []byte
+}
+
+// SignedInvoice is the result of Sign: the Phase-2 QR payload and the UBL
+// 2.1 XML document it was embedded into, both ready for ZATCA submission.
+type SignedInvoice struct {
+	QR  string
+	XML []byte
+	// InvoiceHash is the base64 SHA-256 hash of the canonicalized XML before
+	// the QR was embedded - feed it into the next invoice in the chain's
+	// Invoice.PreviousInvoiceHash (the PIH) to keep ZATCA's hash chain intact.
+	InvoiceHash string
+}
+
+// Sign builds the UBL 2.1 XML for inv, canonicalizes it, hashes it with
+// SHA-256, signs the hash with cert's ECDSA P-256 key, and returns the
+// resulting Phase-2 QR payload together with the signed XML document with
+// that QR embedded as a cac:AdditionalDocumentReference.
+//
+// It is the cert-driven counterpart to BuildQR: where BuildQR expects the
+// hash/signature/public key to already be computed, Sign computes them
+// itself from inv and cert, so callers with a signing key don't have to
+// canonicalize and hash the XML by hand.
+func Sign(inv models.Invoice, cert Cert, ts string) (SignedInvoice, error) {
+	if cert.PrivateKey == nil {
+		return SignedInvoice{}, fmt.Errorf("zatca: cert.PrivateKey is required")
+	}
+
+	doc := buildUBLDoc(inv)
+	canonical, err := canonicalize(doc)
+	if err != nil {
+		return SignedInvoice{}, fmt.Errorf("zatca: canonicalize XML: %w", err)
+	}
+
+	hash := sha256.Sum256(canonical)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, cert.PrivateKey, hash[:])
+	if err != nil {
+		return SignedInvoice{}, fmt.Errorf("zatca: sign invoice hash: %w", err)
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&cert.PrivateKey.PublicKey)
+	if err != nil {
+		return SignedInvoice{}, fmt.Errorf("zatca: marshal public key: %w", err)
+	}
+
+	qr, err := BuildQR(Invoice{
+		SellerName:     inv.StoreName,
+		VATNumber:      inv.VATRegistrationNo,
+		Timestamp:      ts,
+		TotalWithVAT:   inv.TotalWithVAT,
+		VATTotal:       inv.TotalVAT,
+		XMLHash:        hash[:],
+		Signature:      signature,
+		PublicKey:      publicKey,
+		StampSignature: cert.StampSignature,
+	})
+	if err != nil {
+		return SignedInvoice{}, err
+	}
+
+	doc.AdditionalDocRefs = append(doc.AdditionalDocRefs, ublAdditionalDocRef{ID: "QR", Attachment: qr})
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return SignedInvoice{}, fmt.Errorf("zatca: marshal signed UBL invoice: %w", err)
+	}
+
+	return SignedInvoice{
+		QR:          qr,
+		XML:         append([]byte(xml.Header), out...),
+		InvoiceHash: base64.StdEncoding.EncodeToString(hash[:]),
+	}, nil
+}
+
+// canonicalize renders doc without the indentation xml.MarshalIndent adds,
+// so the bytes hashed and signed are stable regardless of how the document
+// is later pretty-printed for display or submission.
+func canonicalize(doc ublInvoice) ([]byte, error) {
+	out, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GenerateP256Key generates a fresh ECDSA P-256 key pair, suitable for
+// testing or for a Cert awaiting a CSID-issued StampSignature.
+func GenerateP256Key() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
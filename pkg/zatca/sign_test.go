@@ -0,0 +1,79 @@
+package zatca
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestSign_MissingPrivateKey(t *testing.T) {
+	if _, err := Sign(testInvoice(), Cert{}, "2024-01-15T10:30:00Z"); err == nil {
+		t.Error("expected an error when Cert.PrivateKey is missing")
+	}
+}
+
+func TestSign_ProducesVerifiableSignatureOverTheXMLHash(t *testing.T) {
+	key, err := GenerateP256Key()
+	if err != nil {
+		t.Fatalf("GenerateP256Key failed: %v", err)
+	}
+
+	signed, err := Sign(testInvoice(), Cert{PrivateKey: key, StampSignature: []byte("stamp")}, "2024-01-15T10:30:00Z")
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	if !strings.Contains(string(signed.XML), "<cac:AdditionalDocumentReference>") {
+		t.Error("expected the QR code to be embedded as an AdditionalDocumentReference")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(signed.QR)
+	if err != nil {
+		t.Fatalf("QR is not valid base64: %v", err)
+	}
+
+	fields := parseTLV(t, raw)
+	if len(fields[TagXMLHash]) != sha256.Size {
+		t.Fatalf("expected a %d-byte SHA-256 hash, got %d bytes", sha256.Size, len(fields[TagXMLHash]))
+	}
+
+	doc := buildUBLDoc(testInvoice())
+	canonical, err := canonicalize(doc)
+	if err != nil {
+		t.Fatalf("canonicalize failed: %v", err)
+	}
+	wantHash := sha256.Sum256(canonical)
+	if string(fields[TagXMLHash]) != string(wantHash[:]) {
+		t.Error("QR hash does not match the hash of the canonicalized XML")
+	}
+
+	if !ecdsa.VerifyASN1(&key.PublicKey, wantHash[:], fields[TagSignature]) {
+		t.Error("QR signature does not verify against the signed hash and public key")
+	}
+
+	if string(fields[TagStampSig]) != "stamp" {
+		t.Errorf("expected stamp signature %q, got %q", "stamp", fields[TagStampSig])
+	}
+}
+
+// parseTLV decodes raw TLV bytes into a map of tag to value, failing the
+// test on malformed input.
+func parseTLV(t *testing.T, raw []byte) map[byte][]byte {
+	t.Helper()
+	fields := map[byte][]byte{}
+	for i := 0; i < len(raw); {
+		if i+2 > len(raw) {
+			t.Fatalf("truncated TLV field header at offset %d", i)
+		}
+		tag, length := raw[i], raw[i+1]
+		i += 2
+		if i+int(length) > len(raw) {
+			t.Fatalf("truncated TLV field value at offset %d", i)
+		}
+		fields[tag] = raw[i : i+int(length)]
+		i += int(length)
+	}
+	return fields
+}
@@ -0,0 +1,271 @@
+package zatca
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"bill-generator/pkg/models"
+)
+
+// ublInvoice is a minimal OASIS UBL 2.1 Invoice document covering the
+// fields ZATCA requires for a simplified tax invoice, plus the
+// Customer/tax-subtotal/line-allowance blocks EN 16931 expects from a
+// standard (non-simplified) invoice.
+type ublInvoice struct {
+	XMLName           xml.Name              `xml:"Invoice"`
+	Xmlns             string                `xml:"xmlns,attr"`
+	ID                string                `xml:"cbc:ID"`
+	UUID              string                `xml:"cbc:UUID,omitempty"`
+	IssueDate         string                `xml:"cbc:IssueDate"`
+	IssueTime         string                `xml:"cbc:IssueTime,omitempty"`
+	ProfileID         string                `xml:"cbc:ProfileID,omitempty"`
+	DocCurrency       string                `xml:"cbc:DocumentCurrencyCode"`
+	AdditionalDocRefs []ublAdditionalDocRef `xml:"cac:AdditionalDocumentReference,omitempty"`
+	BillingRefs       []ublBillingRef       `xml:"cac:BillingReference,omitempty"`
+	Supplier          ublParty              `xml:"cac:AccountingSupplierParty"`
+	Customer          *ublParty             `xml:"cac:AccountingCustomerParty,omitempty"`
+	TaxTotal          ublTaxTotal           `xml:"cac:TaxTotal"`
+	LegalTotal        ublLegalTotal         `xml:"cac:LegalMonetaryTotal"`
+	Lines             []ublLine             `xml:"cac:InvoiceLine"`
+}
+
+// ublAdditionalDocRef carries the ZATCA Phase-2 Base64 TLV QR code as an
+// embedded binary object, per the Fatoora implementation standards.
+type ublAdditionalDocRef struct {
+	ID         string `xml:"cbc:ID"`
+	Attachment string `xml:"cac:Attachment>cbc:EmbeddedDocumentBinaryObject"`
+}
+
+// ublBillingRef carries a preceding invoice reference, required on credit
+// and debit notes per UBL's BillingReference/InvoiceDocumentReference block.
+type ublBillingRef struct {
+	ID        string `xml:"cac:InvoiceDocumentReference>cbc:ID"`
+	IssueDate string `xml:"cac:InvoiceDocumentReference>cbc:IssueDate,omitempty"`
+}
+
+type ublParty struct {
+	Name    string `xml:"cac:Party>cac:PartyName>cbc:Name"`
+	Address string `xml:"cac:Party>cac:PostalAddress>cbc:StreetName,omitempty"`
+	VATID   string `xml:"cac:Party>cac:PartyTaxScheme>cbc:CompanyID"`
+}
+
+// ublTaxTotal aggregates the invoice's total VAT plus one Subtotal per
+// distinct tax rate (UBL requires this breakdown even when every line
+// shares the same rate).
+type ublTaxTotal struct {
+	TaxAmount string           `xml:"cbc:TaxAmount"`
+	Subtotals []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+// ublTaxSubtotal is the taxable amount, tax amount, and rate for one VAT
+// category present on the invoice (e.g. "S" at 15%, "Z" at 0%).
+type ublTaxSubtotal struct {
+	TaxableAmount string `xml:"cbc:TaxableAmount"`
+	TaxAmount     string `xml:"cbc:TaxAmount"`
+	CategoryID    string `xml:"cac:TaxCategory>cbc:ID"`
+	Percent       string `xml:"cac:TaxCategory>cbc:Percent"`
+}
+
+type ublLegalTotal struct {
+	TaxExclusiveAmount string `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount string `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount      string `xml:"cbc:PayableAmount"`
+}
+
+type ublLine struct {
+	ID               string               `xml:"cbc:ID"`
+	Quantity         string               `xml:"cbc:InvoicedQuantity"`
+	LineExtAmt       string               `xml:"cbc:LineExtensionAmount"`
+	AllowanceCharges []ublAllowanceCharge `xml:"cac:AllowanceCharge,omitempty"`
+	ItemName         string               `xml:"cac:Item>cbc:Name"`
+	UnitPrice        string               `xml:"cac:Price>cbc:PriceAmount"`
+}
+
+// ublAllowanceCharge is a single UBL AllowanceCharge block on an invoice
+// line - ChargeIndicator false for a discount, true for a charge/fee.
+type ublAllowanceCharge struct {
+	ChargeIndicator bool   `xml:"cbc:ChargeIndicator"`
+	Amount          string `xml:"cbc:Amount"`
+}
+
+// defaultVATCategory is the UBL tax category code applied to products that
+// don't set VATCategory, matching the loader's "standard rate" default.
+const defaultVATCategory = "S"
+
+// documentUUID derives a stable UUID-shaped identifier for inv's cbc:UUID
+// from its invoice number and ICV, rather than a random one, so re-marshaling
+// the same invoice (e.g. for Sign's hash-then-embed step) produces identical
+// XML.
+func documentUUID(inv models.Invoice) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", inv.InvoiceNumber, inv.InvoiceCounter)))
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
+
+// seedPIH is the PIH (previous invoice hash) ZATCA mandates for the first
+// invoice in a chain: base64(SHA256("0")), per the Fatoora implementation
+// standards.
+var seedPIH = func() string {
+	h := sha256.Sum256([]byte("0"))
+	return base64.StdEncoding.EncodeToString(h[:])
+}()
+
+func buildUBLDoc(inv models.Invoice) ublInvoice {
+	doc := ublInvoice{
+		Xmlns:       "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2",
+		ID:          inv.InvoiceNumber,
+		UUID:        documentUUID(inv),
+		IssueDate:   inv.Date,
+		ProfileID:   "reporting:1.0",
+		DocCurrency: "SAR",
+		Supplier: ublParty{
+			Name:  inv.StoreName,
+			VATID: inv.VATRegistrationNo,
+		},
+		TaxTotal: ublTaxTotal{
+			TaxAmount: fmt.Sprintf("%.2f", inv.TotalVAT),
+			Subtotals: buildTaxSubtotals(inv),
+		},
+		LegalTotal: ublLegalTotal{
+			TaxExclusiveAmount: fmt.Sprintf("%.2f", inv.TotalTaxableAmt),
+			TaxInclusiveAmount: fmt.Sprintf("%.2f", inv.TotalWithVAT),
+			PayableAmount:      fmt.Sprintf("%.2f", inv.TotalWithVAT),
+		},
+	}
+
+	if inv.Compliance == "zatca" {
+		pih := inv.PreviousInvoiceHash
+		if pih == "" {
+			pih = seedPIH
+		}
+		doc.AdditionalDocRefs = append(doc.AdditionalDocRefs,
+			ublAdditionalDocRef{ID: "ICV", Attachment: fmt.Sprintf("%d", inv.InvoiceCounter)},
+			ublAdditionalDocRef{ID: "PIH", Attachment: pih},
+		)
+	}
+
+	for _, ref := range inv.PrecedingReferences {
+		doc.BillingRefs = append(doc.BillingRefs, ublBillingRef{ID: ref.InvoiceNumber, IssueDate: ref.IssueDate})
+	}
+
+	if inv.Customer.Name != "" || inv.Customer.VATNumber != "" {
+		doc.Customer = &ublParty{
+			Name:    inv.Customer.Name,
+			Address: inv.Customer.Address,
+			VATID:   inv.Customer.VATNumber,
+		}
+	}
+
+	for i, p := range inv.Products {
+		line := ublLine{
+			ID:         fmt.Sprintf("%d", i+1),
+			Quantity:   fmt.Sprintf("%.2f", p.Quantity),
+			LineExtAmt: fmt.Sprintf("%.2f", p.NetAmount),
+			ItemName:   p.Name,
+			UnitPrice:  fmt.Sprintf("%.2f", p.UnitPrice),
+		}
+		if p.DiscountAmount != 0 {
+			line.AllowanceCharges = append(line.AllowanceCharges, ublAllowanceCharge{ChargeIndicator: false, Amount: fmt.Sprintf("%.2f", p.DiscountAmount)})
+		}
+		if p.ChargeAmount != 0 {
+			line.AllowanceCharges = append(line.AllowanceCharges, ublAllowanceCharge{ChargeIndicator: true, Amount: fmt.Sprintf("%.2f", p.ChargeAmount)})
+		}
+		doc.Lines = append(doc.Lines, line)
+	}
+
+	return doc
+}
+
+// buildTaxSubtotals groups products by VAT category and returns one
+// TaxSubtotal per category, sorted by category ID for deterministic output.
+func buildTaxSubtotals(inv models.Invoice) []ublTaxSubtotal {
+	type bucket struct {
+		taxable, tax float64
+	}
+	buckets := map[string]*bucket{}
+	var order []string
+
+	for _, p := range inv.Products {
+		category := p.VATCategory
+		if category == "" {
+			category = defaultVATCategory
+		}
+		b, ok := buckets[category]
+		if !ok {
+			b = &bucket{}
+			buckets[category] = b
+			order = append(order, category)
+		}
+		b.taxable += p.TaxableAmt
+		b.tax += p.VATAmount
+	}
+	sort.Strings(order)
+
+	subtotals := make([]ublTaxSubtotal, 0, len(order))
+	for _, category := range order {
+		b := buckets[category]
+		percent := 0.0
+		if b.taxable != 0 {
+			percent = b.tax / b.taxable * 100
+		}
+		subtotals = append(subtotals, ublTaxSubtotal{
+			TaxableAmount: fmt.Sprintf("%.2f", b.taxable),
+			TaxAmount:     fmt.Sprintf("%.2f", b.tax),
+			CategoryID:    category,
+			Percent:       fmt.Sprintf("%.2f", percent),
+		})
+	}
+	return subtotals
+}
+
+// MarshalUBL serializes inv as a ZATCA-compatible UBL 2.1 invoice document.
+func MarshalUBL(inv models.Invoice) ([]byte, error) {
+	out, err := xml.MarshalIndent(buildUBLDoc(inv), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("zatca: failed to marshal UBL invoice: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// ZATCAOptions carries the fields MarshalZATCA needs that models.Invoice
+// doesn't itself track: the QR code timestamp, and the Phase-2
+// cryptographic fields (leave all four empty for the Phase-1/simplified
+// QR fallback - see Invoice's equivalent fields in zatca.go).
+type ZATCAOptions struct {
+	Timestamp      string // ISO 8601, e.g. 2024-01-15T10:30:00Z
+	XMLHash        []byte
+	Signature      []byte
+	PublicKey      []byte
+	StampSignature []byte
+}
+
+// MarshalZATCA serializes inv as a UBL 2.1 invoice document with the ZATCA
+// TLV QR code embedded as a cac:AdditionalDocumentReference, per the
+// Fatoora Phase-2 implementation standards.
+func MarshalZATCA(inv models.Invoice, opts ZATCAOptions) ([]byte, error) {
+	qr, err := BuildQR(Invoice{
+		SellerName:     inv.StoreName,
+		VATNumber:      inv.VATRegistrationNo,
+		Timestamp:      opts.Timestamp,
+		TotalWithVAT:   inv.TotalWithVAT,
+		VATTotal:       inv.TotalVAT,
+		XMLHash:        opts.XMLHash,
+		Signature:      opts.Signature,
+		PublicKey:      opts.PublicKey,
+		StampSignature: opts.StampSignature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doc := buildUBLDoc(inv)
+	doc.AdditionalDocRefs = append(doc.AdditionalDocRefs, ublAdditionalDocRef{ID: "QR", Attachment: qr})
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("zatca: failed to marshal UBL invoice: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,95 @@
+// Package zatca builds the Saudi ZATCA ("Fatoora") e-invoicing QR payload and
+// UBL 2.1 XML representation for a simplified tax invoice.
+package zatca
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// TLV tags for the ZATCA simplified tax invoice QR, in the order the spec
+// requires them to appear. Tags 1-5 are mandatory for both Phase-1 and
+// Phase-2; tags 6-9 are Phase-2 only.
+const (
+	TagSellerName   byte = 1
+	TagVATNumber    byte = 2
+	TagTimestamp    byte = 3
+	TagTotalWithVAT byte = 4
+	TagVATTotal     byte = 5
+	TagXMLHash      byte = 6
+	TagSignature    byte = 7
+	TagPublicKey    byte = 8
+	TagStampSig     byte = 9
+)
+
+// Field is a single TLV-encoded QR field: a 1-byte tag, a 1-byte length, and
+// the value bytes. Phase-2 fields (hash/signature/public key/stamp signature)
+// are raw binary, so Value is []byte rather than string.
+type Field struct {
+	Tag   byte
+	Value []byte
+}
+
+// BuildTLV concatenates fields as tag(1 byte) | length(1 byte) | value,
+// in the order given, per the ZATCA QR encoding.
+func BuildTLV(fields ...Field) []byte {
+	var buf []byte
+	for _, f := range fields {
+		buf = append(buf, f.Tag, byte(len(f.Value)))
+		buf = append(buf, f.Value...)
+	}
+	return buf
+}
+
+// Invoice carries the fields needed to build a ZATCA QR payload.
+type Invoice struct {
+	SellerName   string
+	VATNumber    string
+	Timestamp    string // ISO 8601, e.g. 2024-01-15T10:30:00Z
+	TotalWithVAT float64
+	VATTotal     float64
+
+	// Phase-2 only. Leave all four empty to emit the Phase-1/simplified
+	// fallback (tags 1-5 only).
+	XMLHash        []byte // SHA-256 hash of the canonicalized XML invoice
+	Signature      []byte // ECDSA signature of XMLHash
+	PublicKey      []byte
+	StampSignature []byte // CSID signature over PublicKey
+}
+
+// BuildQR builds the Base64-encoded TLV QR payload mandated by ZATCA for
+// simplified tax invoices (tags 1-5), extended with the Phase-2 cryptographic
+// fields (tags 6-9) when inv.XMLHash is set.
+func BuildQR(inv Invoice) (string, error) {
+	if inv.SellerName == "" {
+		return "", fmt.Errorf("zatca: seller name is required")
+	}
+	if inv.VATNumber == "" {
+		return "", fmt.Errorf("zatca: VAT number is required")
+	}
+
+	fields := []Field{
+		{TagSellerName, []byte(inv.SellerName)},
+		{TagVATNumber, []byte(inv.VATNumber)},
+		{TagTimestamp, []byte(inv.Timestamp)},
+		{TagTotalWithVAT, []byte(fmt.Sprintf("%.2f", inv.TotalWithVAT))},
+		{TagVATTotal, []byte(fmt.Sprintf("%.2f", inv.VATTotal))},
+	}
+
+	if len(inv.XMLHash) > 0 {
+		fields = append(fields,
+			Field{TagXMLHash, inv.XMLHash},
+			Field{TagSignature, inv.Signature},
+			Field{TagPublicKey, inv.PublicKey},
+			Field{TagStampSig, inv.StampSignature},
+		)
+	}
+
+	return base64.StdEncoding.EncodeToString(BuildTLV(fields...)), nil
+}
+
+// EncodeQR is BuildQR under the name callers wiring up pdf.Generator's QR
+// code tend to reach for first; the two are identical.
+func EncodeQR(inv Invoice) (string, error) {
+	return BuildQR(inv)
+}
@@ -0,0 +1,270 @@
+package zatca
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"bill-generator/pkg/models"
+)
+
+func TestBuildTLV(t *testing.T) {
+	tlv := BuildTLV(Field{Tag: 1, Value: []byte("ABC")})
+	want := []byte{1, 3, 'A', 'B', 'C'}
+	if len(tlv) != len(want) {
+		t.Fatalf("expected %d bytes, got %d", len(want), len(tlv))
+	}
+	for i := range want {
+		if tlv[i] != want[i] {
+			t.Fatalf("byte %d: expected %d, got %d", i, want[i], tlv[i])
+		}
+	}
+}
+
+func TestBuildQR_Phase1(t *testing.T) {
+	qr, err := BuildQR(Invoice{
+		SellerName:   "Test Store",
+		VATNumber:    "123456789",
+		Timestamp:    "2024-01-15T10:30:00Z",
+		TotalWithVAT: 115.00,
+		VATTotal:     15.00,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(qr)
+	if err != nil {
+		t.Fatalf("QR is not valid base64: %v", err)
+	}
+	if raw[0] != TagSellerName || raw[1] != byte(len("Test Store")) {
+		t.Errorf("expected first TLV field to be seller name, got tag=%d len=%d", raw[0], raw[1])
+	}
+}
+
+func TestEncodeQR_ExactByteLayout(t *testing.T) {
+	qr, err := EncodeQR(Invoice{
+		SellerName:   "ACME",
+		VATNumber:    "300000000000003",
+		Timestamp:    "2022-04-25T15:30:00Z",
+		TotalWithVAT: 100.00,
+		VATTotal:     13.04,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(qr)
+	if err != nil {
+		t.Fatalf("QR is not valid base64: %v", err)
+	}
+
+	want := BuildTLV(
+		Field{TagSellerName, []byte("ACME")},
+		Field{TagVATNumber, []byte("300000000000003")},
+		Field{TagTimestamp, []byte("2022-04-25T15:30:00Z")},
+		Field{TagTotalWithVAT, []byte("100.00")},
+		Field{TagVATTotal, []byte("13.04")},
+	)
+	if string(raw) != string(want) {
+		t.Errorf("EncodeQR TLV bytes = %v, want %v", raw, want)
+	}
+}
+
+func TestBuildQR_Phase2IncludesCryptoFields(t *testing.T) {
+	qr, err := BuildQR(Invoice{
+		SellerName:     "Test Store",
+		VATNumber:      "123456789",
+		Timestamp:      "2024-01-15T10:30:00Z",
+		TotalWithVAT:   115.00,
+		VATTotal:       15.00,
+		XMLHash:        []byte("hash"),
+		Signature:      []byte("sig"),
+		PublicKey:      []byte("pub"),
+		StampSignature: []byte("stamp"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(qr)
+	if err != nil {
+		t.Fatalf("QR is not valid base64: %v", err)
+	}
+
+	var tags []byte
+	for i := 0; i < len(raw); {
+		tag, length := raw[i], raw[i+1]
+		tags = append(tags, tag)
+		i += 2 + int(length)
+	}
+	if len(tags) != 9 {
+		t.Fatalf("expected 9 TLV fields in phase-2 mode, got %d", len(tags))
+	}
+}
+
+func TestBuildQR_MissingSellerName(t *testing.T) {
+	if _, err := BuildQR(Invoice{VATNumber: "123"}); err == nil {
+		t.Error("expected an error when seller name is missing")
+	}
+}
+
+func testInvoice() models.Invoice {
+	return models.Invoice{
+		InvoiceNumber:     "INV-001",
+		StoreName:         "Test Store",
+		Date:              "2024-01-15",
+		VATRegistrationNo: "123456789",
+		Customer: models.Customer{
+			Name:      "Acme Corp",
+			VATNumber: "987654321",
+		},
+		Products: []models.Product{
+			{Name: "Widget", Quantity: 2, UnitPrice: 50, GrossAmount: 100, NetAmount: 90, TaxableAmt: 90, DiscountAmount: 10, VATAmount: 13.5, VATCategory: "S"},
+			{Name: "Export Item", Quantity: 1, UnitPrice: 20, GrossAmount: 20, NetAmount: 20, TaxableAmt: 20, VATAmount: 0, VATCategory: "Z"},
+		},
+		TotalTaxableAmt: 110,
+		TotalVAT:        13.5,
+		TotalWithVAT:    123.5,
+	}
+}
+
+func TestMarshalUBL_IncludesCustomerAndSubtotalsPerRate(t *testing.T) {
+	xmlBytes, err := MarshalUBL(testInvoice())
+	if err != nil {
+		t.Fatalf("MarshalUBL failed: %v", err)
+	}
+	doc := string(xmlBytes)
+
+	if !strings.Contains(doc, "Acme Corp") {
+		t.Error("expected customer name in UBL output")
+	}
+	if !strings.Contains(doc, "987654321") {
+		t.Error("expected customer VAT number in UBL output")
+	}
+	if strings.Count(doc, "<cac:TaxSubtotal>") != 2 {
+		t.Errorf("expected one TaxSubtotal per VAT category, got:\n%s", doc)
+	}
+}
+
+func TestMarshalUBL_LineAllowanceCharge(t *testing.T) {
+	xmlBytes, err := MarshalUBL(testInvoice())
+	if err != nil {
+		t.Fatalf("MarshalUBL failed: %v", err)
+	}
+	doc := string(xmlBytes)
+
+	if !strings.Contains(doc, "<cac:AllowanceCharge>") {
+		t.Error("expected a line-level AllowanceCharge block for the discounted product")
+	}
+}
+
+func TestMarshalUBL_CreditNoteIncludesBillingReference(t *testing.T) {
+	inv := testInvoice()
+	inv.Type = models.InvoiceTypeCredit
+	inv.PrecedingReferences = []models.DocumentRef{{InvoiceNumber: "INV-100", IssueDate: "2024-01-10", Reason: "returned goods"}}
+
+	xmlBytes, err := MarshalUBL(inv)
+	if err != nil {
+		t.Fatalf("MarshalUBL failed: %v", err)
+	}
+	doc := string(xmlBytes)
+
+	if !strings.Contains(doc, "<cac:BillingReference>") {
+		t.Error("expected a BillingReference block for a credit note")
+	}
+	if !strings.Contains(doc, "INV-100") {
+		t.Error("expected the referenced invoice number in the BillingReference block")
+	}
+}
+
+func TestMarshalUBL_StandardInvoiceOmitsBillingReference(t *testing.T) {
+	xmlBytes, err := MarshalUBL(testInvoice())
+	if err != nil {
+		t.Fatalf("MarshalUBL failed: %v", err)
+	}
+	if strings.Contains(string(xmlBytes), "<cac:BillingReference>") {
+		t.Error("expected no BillingReference block on a standard invoice")
+	}
+}
+
+func TestMarshalZATCA_EmbedsQRCode(t *testing.T) {
+	xmlBytes, err := MarshalZATCA(testInvoice(), ZATCAOptions{Timestamp: "2024-01-15T10:30:00Z"})
+	if err != nil {
+		t.Fatalf("MarshalZATCA failed: %v", err)
+	}
+	doc := string(xmlBytes)
+
+	if !strings.Contains(doc, "<cac:AdditionalDocumentReference>") {
+		t.Error("expected the ZATCA QR code to be embedded as an AdditionalDocumentReference")
+	}
+}
+
+func TestMarshalUBL_NonZATCAOmitsICVAndPIH(t *testing.T) {
+	xmlBytes, err := MarshalUBL(testInvoice())
+	if err != nil {
+		t.Fatalf("MarshalUBL failed: %v", err)
+	}
+	doc := string(xmlBytes)
+
+	if strings.Contains(doc, ">ICV<") || strings.Contains(doc, ">PIH<") {
+		t.Error("expected no ICV/PIH document references on a non-ZATCA invoice")
+	}
+}
+
+func TestMarshalZATCA_IncludesICVAndPIH(t *testing.T) {
+	inv := testInvoice()
+	inv.Compliance = "zatca"
+	inv.InvoiceCounter = 42
+
+	xmlBytes, err := MarshalZATCA(inv, ZATCAOptions{Timestamp: "2024-01-15T10:30:00Z"})
+	if err != nil {
+		t.Fatalf("MarshalZATCA failed: %v", err)
+	}
+	doc := string(xmlBytes)
+
+	if !strings.Contains(doc, ">42<") {
+		t.Errorf("expected the ICV (42) in the output, got:\n%s", doc)
+	}
+	if !strings.Contains(doc, ">"+seedPIH+"<") {
+		t.Errorf("expected the seed PIH (no PreviousInvoiceHash set) in the output, got:\n%s", doc)
+	}
+}
+
+func TestMarshalZATCA_UsesSuppliedPIH(t *testing.T) {
+	inv := testInvoice()
+	inv.Compliance = "zatca"
+	inv.PreviousInvoiceHash = "cHJldmlvdXMtaGFzaA=="
+
+	xmlBytes, err := MarshalZATCA(inv, ZATCAOptions{Timestamp: "2024-01-15T10:30:00Z"})
+	if err != nil {
+		t.Fatalf("MarshalZATCA failed: %v", err)
+	}
+	if !strings.Contains(string(xmlBytes), inv.PreviousInvoiceHash) {
+		t.Error("expected the supplied PreviousInvoiceHash to carry through as the PIH")
+	}
+}
+
+func TestSign_ReturnsChainableInvoiceHash(t *testing.T) {
+	key, err := GenerateP256Key()
+	if err != nil {
+		t.Fatalf("GenerateP256Key failed: %v", err)
+	}
+
+	inv := testInvoice()
+	inv.Compliance = "zatca"
+	signed, err := Sign(inv, Cert{PrivateKey: key}, "2024-01-15T10:30:00Z")
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if signed.InvoiceHash == "" {
+		t.Error("expected a non-empty InvoiceHash to chain into the next invoice's PreviousInvoiceHash")
+	}
+
+	inv2 := testInvoice()
+	inv2.Compliance = "zatca"
+	inv2.PreviousInvoiceHash = signed.InvoiceHash
+	if _, err := Sign(inv2, Cert{PrivateKey: key}, "2024-01-16T10:30:00Z"); err != nil {
+		t.Fatalf("Sign with chained PIH failed: %v", err)
+	}
+}